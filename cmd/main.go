@@ -2,12 +2,15 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"flag"
 	"log"
 	"net/http"
 	"os"
-	"os/exec"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -19,13 +22,59 @@ import (
 	specsadapter "github.com/restartfu/grid-node/internal/adapters/specs"
 	"github.com/restartfu/grid-node/internal/adapters/xmrig"
 	"github.com/restartfu/grid-node/internal/app"
+	"github.com/restartfu/grid-node/internal/domain"
 	"github.com/restartfu/grid-node/internal/observability"
+	"github.com/restartfu/grid-node/internal/schedule"
+	"github.com/restartfu/grid-node/internal/stratum"
 )
 
 func main() {
 	addr := flag.String("addr", "0.0.0.0:8080", "listen address")
-	xmrigArgsFlag := flag.String("xmrig-args", "", "xmrig args, space-separated; overrides defaults")
+	xmrigBinaryFlag := flag.String("xmrig-binary", "", "xmrig executable; defaults to xmrig in PATH")
+	xmrigMinVersionFlag := flag.String("xmrig-min-version", "", "oldest xmrig version grid-node starts, like 6.21.0; empty accepts any")
+	xmrigSHA256Flag := flag.String("xmrig-sha256", "", "comma-separated SHA-256 hashes the xmrig binary must match before every launch; empty disables pinning")
+	xmrigArgsFlag := flag.String("xmrig-args", "", "removed; use -xmrig-config for pool and miner settings and -xmrig-extra-args for extra flags")
+	xmrigExtraArgsFlag := flag.String("xmrig-extra-args", "", "extra xmrig args, space-separated; appended after --config so they override the rendered file")
+	xmrigConfigFlag := flag.String("xmrig-config", "", "JSON file with the typed xmrig config; empty uses the defaults")
+	xmrigRenderedConfigFlag := flag.String("xmrig-rendered-config", "", "path the xmrig config.json is rendered to before every launch; defaults to grid-node/xmrig.json in the user cache directory")
 	xmrigRestartDelayFlag := flag.Duration("xmrig-restart-delay", 0, "xmrig restart delay")
+	xmrigAPIPortFlag := flag.Int("xmrig-api-port", 0, "xmrig HTTP API port on localhost; 0 picks a free port, -1 disables the API")
+	xmrigMaxRestartDelayFlag := flag.Duration("xmrig-max-restart-delay", 0, "cap for the exponential xmrig restart backoff")
+	xmrigStableUptimeFlag := flag.Duration("xmrig-stable-uptime", 0, "xmrig uptime after which the restart backoff resets")
+	xmrigCrashLoopExitsFlag := flag.Int("xmrig-crash-loop-exits", 0, "xmrig exits within the crash-loop window that mark a crash loop")
+	xmrigCrashLoopWindowFlag := flag.Duration("xmrig-crash-loop-window", 0, "window for xmrig crash-loop detection")
+	xmrigLogBufferFlag := flag.Int("xmrig-log-buffer", 0, "number of xmrig log lines kept in memory")
+	xmrigHashrateHistoryFlag := flag.Int("xmrig-hashrate-history", 0, "number of xmrig hashrate samples kept in memory")
+	xmrigResourceIntervalFlag := flag.Duration("xmrig-resource-interval", 0, "how often the xmrig process is sampled from /proc")
+	xmrigResourceHistoryFlag := flag.Int("xmrig-resource-history", 0, "number of xmrig resource samples kept in memory")
+	xmrigBenchmarkFileFlag := flag.String("xmrig-benchmark-file", "", "path of the JSON lines file benchmark results are appended to; empty keeps them in memory")
+	xmrigBenchmarkTimeoutFlag := flag.Duration("xmrig-benchmark-timeout", 0, "time after which an xmrig benchmark run is stopped")
+	xmrigTuneTimeoutFlag := flag.Duration("xmrig-tune-timeout", 0, "time after which an xmrig auto-tune sweep is stopped")
+	xmrigTuneFileFlag := flag.String("xmrig-tune-file", "", "path the xmrig auto-tune profile is saved to and loaded from; empty keeps it in memory")
+	xmrigStopGraceFlag := flag.Duration("xmrig-stop-grace", 0, "time xmrig gets to exit after SIGTERM before it is killed")
+	xmrigLogFileFlag := flag.String("xmrig-log-file", "", "path of the persistent xmrig log; empty disables it")
+	xmrigLogMaxSizeFlag := flag.Int("xmrig-log-max-size", 0, "size in MB after which the xmrig log file is rotated")
+	xmrigLogMaxAgeFlag := flag.Duration("xmrig-log-max-age", 0, "age after which the xmrig log file is rotated")
+	xmrigLogMaxBackupsFlag := flag.Int("xmrig-log-max-backups", 0, "number of rotated xmrig log files kept")
+	thermalTripFlag := flag.Float64("thermal-trip", 0, "CPU temperature in C at which mining is paused or throttled; 0 disables the thermal guard")
+	thermalResumeFlag := flag.Float64("thermal-resume", 0, "CPU temperature in C below which mining resumes after a thermal trip")
+	thermalMinHoldFlag := flag.Duration("thermal-min-hold", 0, "minimum time mining stays paused or throttled after a thermal trip")
+	thermalIntervalFlag := flag.Duration("thermal-interval", 0, "how often the thermal guard reads the CPU temperature")
+	thermalActionFlag := flag.String("thermal-action", "", "thermal guard action: pause or throttle")
+	thermalThreadsFlag := flag.Int("thermal-throttle-threads", 0, "xmrig threads while throttled; defaults to half the CPUs")
+	miningModeFlag := flag.String("mining-mode", "", "always, or idle to mine only while other processes leave the CPU idle")
+	idleThresholdFlag := flag.Float64("idle-threshold", 0, "non-miner CPU load in percent of all CPUs that counts as busy in idle mode")
+	idleBusyForFlag := flag.Duration("idle-busy-for", 0, "how long the host must stay busy before idle mode pauses xmrig")
+	idleResumeAfterFlag := flag.Duration("idle-resume-after", 0, "how long the host must stay idle before idle mode continues xmrig")
+	idleIntervalFlag := flag.Duration("idle-interval", 0, "how often idle mode samples CPU usage")
+	poolFailoverThresholdFlag := flag.Int("pool-failover-threshold", 0, "consecutive pool failures after which xmrig moves to the next configured pool")
+	poolRetryPrimaryFlag := flag.Duration("pool-retry-primary", 0, "how long a fallback pool is used before the primary pool is retried")
+	poolProbeIntervalFlag := flag.Duration("pool-probe-interval", 0, "how often the active pool is resolved and dialed; negative disables the probe")
+	poolProbeTimeoutFlag := flag.Duration("pool-probe-timeout", 0, "timeout for a single pool probe")
+	stratumProxyFlag := flag.Bool("stratum-proxy", false, "run a local stratum proxy between xmrig and the pool")
+	stratumProxyListenFlag := flag.String("stratum-proxy-listen", "", "listen address of the local stratum proxy; defaults to a free localhost port")
+	stratumProxyMaxOutageFlag := flag.Duration("stratum-proxy-max-outage", 0, "how long the stratum proxy keeps xmrig connected while the pool is unreachable before failing over")
+	scheduleFileFlag := flag.String("schedule-file", "", "JSON file with mining windows; empty mines around the clock")
 	flag.Parse()
 
 	specsReader := specsadapter.NewReader()
@@ -37,38 +86,208 @@ func main() {
 	}
 	defer flushSentry()
 
-	envArgs := strings.TrimSpace(os.Getenv("GRID_XMRIG_ARGS"))
-	envDelay := strings.TrimSpace(os.Getenv("GRID_XMRIG_RESTART_DELAY"))
-
-	argsValue := strings.TrimSpace(*xmrigArgsFlag)
-	if argsValue == "" && envArgs != "" {
-		argsValue = envArgs
+	// -xmrig-args used to replace xmrig's whole command line. Appending it to
+	// the rendered config would add a second pool, so refuse to start.
+	if strings.TrimSpace(*xmrigArgsFlag) != "" || strings.TrimSpace(os.Getenv("GRID_XMRIG_ARGS")) != "" {
+		logger.Printf("-xmrig-args and GRID_XMRIG_ARGS were removed: move pool and miner settings to -xmrig-config (GRID_XMRIG_CONFIG) and pass remaining xmrig flags with -xmrig-extra-args (GRID_XMRIG_EXTRA_ARGS)")
+		os.Exit(1)
+	}
+	extraArgsValue := strings.TrimSpace(*xmrigExtraArgsFlag)
+	if extraArgsValue == "" {
+		extraArgsValue = strings.TrimSpace(os.Getenv("GRID_XMRIG_EXTRA_ARGS"))
 	}
-	var args []string
-	if argsValue != "" {
-		args = strings.Fields(argsValue)
+	var extraArgs []string
+	if extraArgsValue != "" {
+		extraArgs = strings.Fields(extraArgsValue)
 	}
 
-	restartDelay := *xmrigRestartDelayFlag
-	if restartDelay == 0 && envDelay != "" {
-		parsed, err := time.ParseDuration(envDelay)
+	restartDelay := durationSetting(*xmrigRestartDelayFlag, "GRID_XMRIG_RESTART_DELAY")
+	maxRestartDelay := durationSetting(*xmrigMaxRestartDelayFlag, "GRID_XMRIG_MAX_RESTART_DELAY")
+	stableUptime := durationSetting(*xmrigStableUptimeFlag, "GRID_XMRIG_STABLE_UPTIME")
+	crashLoopWindow := durationSetting(*xmrigCrashLoopWindowFlag, "GRID_XMRIG_CRASH_LOOP_WINDOW")
+	stopGrace := durationSetting(*xmrigStopGraceFlag, "GRID_XMRIG_STOP_GRACE")
+	crashLoopExits := intSetting(*xmrigCrashLoopExitsFlag, "GRID_XMRIG_CRASH_LOOP_EXITS")
+	logBufferSize := intSetting(*xmrigLogBufferFlag, "GRID_XMRIG_LOG_BUFFER")
+	hashrateHistorySize := intSetting(*xmrigHashrateHistoryFlag, "GRID_XMRIG_HASHRATE_HISTORY")
+	apiPort := intSetting(*xmrigAPIPortFlag, "GRID_XMRIG_API_PORT")
+	logFile := strings.TrimSpace(*xmrigLogFileFlag)
+	if logFile == "" {
+		logFile = strings.TrimSpace(os.Getenv("GRID_XMRIG_LOG_FILE"))
+	}
+	benchmarkFile := strings.TrimSpace(*xmrigBenchmarkFileFlag)
+	if benchmarkFile == "" {
+		benchmarkFile = strings.TrimSpace(os.Getenv("GRID_XMRIG_BENCHMARK_FILE"))
+	}
+	tuneFile := strings.TrimSpace(*xmrigTuneFileFlag)
+	if tuneFile == "" {
+		tuneFile = strings.TrimSpace(os.Getenv("GRID_XMRIG_TUNE_FILE"))
+	}
+	logMaxSize := intSetting(*xmrigLogMaxSizeFlag, "GRID_XMRIG_LOG_MAX_SIZE")
+	logMaxAge := durationSetting(*xmrigLogMaxAgeFlag, "GRID_XMRIG_LOG_MAX_AGE")
+	logMaxBackups := intSetting(*xmrigLogMaxBackupsFlag, "GRID_XMRIG_LOG_MAX_BACKUPS")
+	thermalAction := strings.TrimSpace(*thermalActionFlag)
+	if thermalAction == "" {
+		thermalAction = strings.TrimSpace(os.Getenv("GRID_THERMAL_ACTION"))
+	}
+	if thermalAction != "" && thermalAction != domain.XMRigThermalPause && thermalAction != domain.XMRigThermalThrottle {
+		logger.Printf("invalid thermal action %q, expected pause or throttle", thermalAction)
+		os.Exit(1)
+	}
+	thermal := xmrig.ThermalConfig{
+		TripC:           floatSetting(*thermalTripFlag, "GRID_THERMAL_TRIP"),
+		ResumeC:         floatSetting(*thermalResumeFlag, "GRID_THERMAL_RESUME"),
+		MinHold:         durationSetting(*thermalMinHoldFlag, "GRID_THERMAL_MIN_HOLD"),
+		Interval:        durationSetting(*thermalIntervalFlag, "GRID_THERMAL_INTERVAL"),
+		Action:          thermalAction,
+		ThrottleThreads: intSetting(*thermalThreadsFlag, "GRID_THERMAL_THROTTLE_THREADS"),
+	}
+	miningMode := strings.TrimSpace(*miningModeFlag)
+	if miningMode == "" {
+		miningMode = strings.TrimSpace(os.Getenv("GRID_MINING_MODE"))
+	}
+	if miningMode != "" && miningMode != domain.XMRigModeAlways && miningMode != domain.XMRigModeIdle {
+		logger.Printf("invalid mining mode %q, expected always or idle", miningMode)
+		os.Exit(1)
+	}
+	idle := xmrig.IdleConfig{
+		Enabled:   miningMode == domain.XMRigModeIdle,
+		Threshold: floatSetting(*idleThresholdFlag, "GRID_IDLE_THRESHOLD"),
+		BusyFor:   durationSetting(*idleBusyForFlag, "GRID_IDLE_BUSY_FOR"),
+		IdleFor:   durationSetting(*idleResumeAfterFlag, "GRID_IDLE_RESUME_AFTER"),
+		Interval:  durationSetting(*idleIntervalFlag, "GRID_IDLE_INTERVAL"),
+	}
+	failover := xmrig.FailoverConfig{
+		Threshold:    intSetting(*poolFailoverThresholdFlag, "GRID_POOL_FAILOVER_THRESHOLD"),
+		RetryPrimary: durationSetting(*poolRetryPrimaryFlag, "GRID_POOL_RETRY_PRIMARY"),
+	}
+	probe := xmrig.ProbeConfig{
+		Interval: durationSetting(*poolProbeIntervalFlag, "GRID_POOL_PROBE_INTERVAL"),
+		Timeout:  durationSetting(*poolProbeTimeoutFlag, "GRID_POOL_PROBE_TIMEOUT"),
+	}
+	var proxy *stratum.Proxy
+	if boolSetting(*stratumProxyFlag, "GRID_STRATUM_PROXY") {
+		listen := strings.TrimSpace(*stratumProxyListenFlag)
+		if listen == "" {
+			listen = strings.TrimSpace(os.Getenv("GRID_STRATUM_PROXY_LISTEN"))
+		}
+		listened, err := stratum.Listen(stratum.Config{
+			Listen:    listen,
+			MaxOutage: durationSetting(*stratumProxyMaxOutageFlag, "GRID_STRATUM_PROXY_MAX_OUTAGE"),
+		})
 		if err != nil {
-			log.Printf("invalid GRID_XMRIG_RESTART_DELAY: %v", err)
+			logger.Printf("stratum proxy: %v", err)
+			os.Exit(1)
+		}
+		proxy = listened
+	}
+	scheduleFile := strings.TrimSpace(*scheduleFileFlag)
+	if scheduleFile == "" {
+		scheduleFile = strings.TrimSpace(os.Getenv("GRID_SCHEDULE_FILE"))
+	}
+	var miningSchedule *schedule.Schedule
+	if scheduleFile != "" {
+		loaded, err := schedule.Load(scheduleFile)
+		if err != nil {
+			logger.Printf("schedule: %v", err)
+			os.Exit(1)
+		}
+		miningSchedule = loaded
+	}
+	minerConfig := xmrig.DefaultMinerConfig()
+	minerConfigFile := strings.TrimSpace(*xmrigConfigFlag)
+	if minerConfigFile == "" {
+		minerConfigFile = strings.TrimSpace(os.Getenv("GRID_XMRIG_CONFIG"))
+	}
+	if minerConfigFile != "" {
+		loaded, err := xmrig.LoadMinerConfig(minerConfigFile)
+		if err != nil {
+			logger.Printf("xmrig config: %v", err)
 			os.Exit(1)
-		} else {
-			restartDelay = parsed
 		}
+		minerConfig = loaded
 	}
-	if _, err := exec.LookPath("xmrig"); err != nil {
-		logger.Printf("xmrig lookup: %v", err)
+	if err := minerConfig.Validate(); err != nil {
+		var fieldErrs xmrig.ValidationErrors
+		if errors.As(err, &fieldErrs) {
+			for _, fieldErr := range fieldErrs {
+				logger.Printf("xmrig config: %v", fieldErr)
+			}
+		} else {
+			logger.Printf("xmrig config: %v", err)
+		}
 		os.Exit(1)
 	}
+	renderedConfig := strings.TrimSpace(*xmrigRenderedConfigFlag)
+	if renderedConfig == "" {
+		renderedConfig = strings.TrimSpace(os.Getenv("GRID_XMRIG_RENDERED_CONFIG"))
+	}
+	xmrigBinary := strings.TrimSpace(*xmrigBinaryFlag)
+	if xmrigBinary == "" {
+		xmrigBinary = strings.TrimSpace(os.Getenv("GRID_XMRIG_BINARY"))
+	}
+	xmrigMinVersion := strings.TrimSpace(*xmrigMinVersionFlag)
+	if xmrigMinVersion == "" {
+		xmrigMinVersion = strings.TrimSpace(os.Getenv("GRID_XMRIG_MIN_VERSION"))
+	}
+	if xmrigMinVersion != "" {
+		if _, err := xmrig.ParseVersion(xmrigMinVersion); err != nil {
+			logger.Printf("xmrig min version: %v", err)
+			os.Exit(1)
+		}
+	}
+	xmrigSHA256 := strings.TrimSpace(*xmrigSHA256Flag)
+	if xmrigSHA256 == "" {
+		xmrigSHA256 = strings.TrimSpace(os.Getenv("GRID_XMRIG_SHA256"))
+	}
+	var allowedSHA256 []string
+	for _, sum := range strings.Split(xmrigSHA256, ",") {
+		sum = strings.ToLower(strings.TrimSpace(sum))
+		if sum == "" {
+			continue
+		}
+		if decoded, err := hex.DecodeString(sum); err != nil || len(decoded) != sha256.Size {
+			logger.Printf("invalid xmrig SHA-256 %q", sum)
+			os.Exit(1)
+		}
+		allowedSHA256 = append(allowedSHA256, sum)
+	}
 	xmrigWrapper := xmrig.NewWrapper(os.Stdout, xmrig.Config{
-		Args:         args,
-		RestartDelay: restartDelay,
+		BinaryPath:          xmrigBinary,
+		MinVersion:          xmrigMinVersion,
+		AllowedSHA256:       allowedSHA256,
+		Miner:               minerConfig,
+		ConfigPath:          renderedConfig,
+		ExtraArgs:           extraArgs,
+		RestartDelay:        restartDelay,
+		MaxRestartDelay:     maxRestartDelay,
+		StableUptime:        stableUptime,
+		CrashLoopExits:      crashLoopExits,
+		CrashLoopWindow:     crashLoopWindow,
+		StopGracePeriod:     stopGrace,
+		LogBufferSize:       logBufferSize,
+		HashrateHistorySize: hashrateHistorySize,
+		APIPort:             apiPort,
+		ResourceInterval:    durationSetting(*xmrigResourceIntervalFlag, "GRID_XMRIG_RESOURCE_INTERVAL"),
+		ResourceHistorySize: intSetting(*xmrigResourceHistoryFlag, "GRID_XMRIG_RESOURCE_HISTORY"),
+		BenchmarkFile:       benchmarkFile,
+		BenchmarkTimeout:    durationSetting(*xmrigBenchmarkTimeoutFlag, "GRID_XMRIG_BENCHMARK_TIMEOUT"),
+		TuneTimeout:         durationSetting(*xmrigTuneTimeoutFlag, "GRID_XMRIG_TUNE_TIMEOUT"),
+		TuneFile:            tuneFile,
+		LogFile: xmrig.LogFileConfig{
+			Path:       logFile,
+			MaxSize:    int64(logMaxSize) << 20,
+			MaxAge:     logMaxAge,
+			MaxBackups: logMaxBackups,
+		},
+		Thermal:  thermal,
+		Idle:     idle,
+		Failover: failover,
+		Proxy:    proxy,
+		Probe:    probe,
+		Schedule: miningSchedule,
 	})
 
-	service := app.NewService(specsReader, specsReader, xmrigWrapper)
+	service := app.NewService(specsReader, specsReader, xmrigWrapper, xmrigWrapper)
 	httpServer := httpadapter.NewServer(service, logger)
 	echoServer := echo.New()
 	echoServer.HideBanner = true
@@ -110,11 +329,16 @@ func main() {
 		Handler:           echoServer,
 		ReadHeaderTimeout: 5 * time.Second,
 	}
+	server.RegisterOnShutdown(httpServer.CloseStreams)
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
 	defer stop()
 
-	go xmrigWrapper.Start(ctx)
+	xmrigDone := make(chan struct{})
+	go func() {
+		defer close(xmrigDone)
+		xmrigWrapper.Start(ctx)
+	}()
 
 	go func() {
 		<-ctx.Done()
@@ -130,4 +354,66 @@ func main() {
 		log.Printf("listen: %v", err)
 		os.Exit(1)
 	}
+	// Give xmrig its grace period to disconnect from the pool before exiting.
+	<-xmrigDone
+}
+
+// durationSetting returns the flag value, falling back to the environment
+// variable when the flag was left at zero.
+func durationSetting(value time.Duration, env string) time.Duration {
+	raw := strings.TrimSpace(os.Getenv(env))
+	if value != 0 || raw == "" {
+		return value
+	}
+	parsed, err := time.ParseDuration(raw)
+	if err != nil {
+		log.Printf("invalid %s: %v", env, err)
+		os.Exit(1)
+	}
+	return parsed
+}
+
+// floatSetting returns the flag value, falling back to the environment
+// variable when the flag was left at zero.
+func floatSetting(value float64, env string) float64 {
+	raw := strings.TrimSpace(os.Getenv(env))
+	if value != 0 || raw == "" {
+		return value
+	}
+	parsed, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		log.Printf("invalid %s: %v", env, err)
+		os.Exit(1)
+	}
+	return parsed
+}
+
+// boolSetting returns the flag value, falling back to the environment
+// variable when the flag was left unset.
+func boolSetting(value bool, env string) bool {
+	raw := strings.TrimSpace(os.Getenv(env))
+	if value || raw == "" {
+		return value
+	}
+	parsed, err := strconv.ParseBool(raw)
+	if err != nil {
+		log.Printf("invalid %s: %v", env, err)
+		os.Exit(1)
+	}
+	return parsed
+}
+
+// intSetting returns the flag value, falling back to the environment variable
+// when the flag was left at zero.
+func intSetting(value int, env string) int {
+	raw := strings.TrimSpace(os.Getenv(env))
+	if value != 0 || raw == "" {
+		return value
+	}
+	parsed, err := strconv.Atoi(raw)
+	if err != nil {
+		log.Printf("invalid %s: %v", env, err)
+		os.Exit(1)
+	}
+	return parsed
 }
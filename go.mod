@@ -6,6 +6,7 @@ require (
 	github.com/getsentry/sentry-go v0.28.1
 	github.com/labstack/echo/v4 v4.11.4
 	github.com/restartfu/grid-node/openapi v1.0.0
+	golang.org/x/sys v0.18.0
 )
 
 require (
@@ -20,7 +21,8 @@ require (
 	github.com/valyala/fasttemplate v1.2.2 // indirect
 	golang.org/x/crypto v0.19.0 // indirect
 	golang.org/x/net v0.21.0 // indirect
-	golang.org/x/sys v0.18.0 // indirect
 	golang.org/x/text v0.22.0 // indirect
 	golang.org/x/time v0.5.0 // indirect
 )
+
+replace github.com/restartfu/grid-node/openapi => ./openapi
@@ -3,20 +3,34 @@ package http
 import (
 	"errors"
 	"log"
+	"sync"
+	"time"
 
 	nethttp "net/http"
 
 	"github.com/labstack/echo/v4"
 	"github.com/restartfu/grid-node/internal/app"
+	"github.com/restartfu/grid-node/internal/domain"
 	"github.com/restartfu/grid-node/internal/observability"
 	"github.com/restartfu/grid-node/openapi/generated"
 )
 
-const maxXmrigLogs = 250
+const defaultXmrigLogs = 250
+
+const maxXmrigLogs = 10000
+
+const defaultHashrateRange = time.Hour
+
+// defaultHashrateBuckets is the bucket count aimed for when no step is given.
+const defaultHashrateBuckets = 120
+
+const maxHashrateBuckets = 10000
 
 type Server struct {
-	service *app.Service
-	logger  *log.Logger
+	service     *app.Service
+	logger      *log.Logger
+	streamsDone chan struct{}
+	closeOnce   sync.Once
 }
 
 func NewServer(service *app.Service, logger *log.Logger) *Server {
@@ -24,21 +38,56 @@ func NewServer(service *app.Service, logger *log.Logger) *Server {
 		logger = log.Default()
 	}
 	return &Server{
-		service: service,
-		logger:  logger,
+		service:     service,
+		logger:      logger,
+		streamsDone: make(chan struct{}),
 	}
 }
 
+// CloseStreams ends open event streams so a graceful shutdown does not wait
+// on them.
+func (s *Server) CloseStreams() {
+	s.closeOnce.Do(func() {
+		close(s.streamsDone)
+	})
+}
+
 func (s *Server) Register(e *echo.Echo) {
 	generated.RegisterHandlers(e, s)
 }
 
-func (s *Server) GetHealth(ctx echo.Context) error {
-	health := s.service.Health()
-	return ctx.JSON(nethttp.StatusOK, generated.Health{
+func (s *Server) GetHealth(ctx echo.Context, params generated.GetHealthParams) error {
+	if params.Detailed == nil || !*params.Detailed {
+		health := s.service.Health()
+		return ctx.JSON(nethttp.StatusOK, generated.Health{
+			Status: health.Status,
+			Time:   health.Time,
+		})
+	}
+	health := s.service.DetailedHealth()
+	response := generated.Health{
 		Status: health.Status,
 		Time:   health.Time,
-	})
+	}
+	checks := make([]generated.HealthCheck, 0, len(health.Checks))
+	for _, check := range health.Checks {
+		item := generated.HealthCheck{Name: check.Name, Status: check.Status}
+		if check.Message != "" {
+			message := check.Message
+			item.Message = &message
+		}
+		checks = append(checks, item)
+	}
+	response.Checks = &checks
+	if health.PoolProbe != nil {
+		probe := xmrigPoolProbeResponse(*health.PoolProbe)
+		response.PoolProbe = &probe
+	}
+	code := nethttp.StatusOK
+	if health.Status != domain.HealthOK {
+		code = nethttp.StatusServiceUnavailable
+	}
+	return ctx.JSON(code, response)
 }
 
 func (s *Server) GetMetrics(ctx echo.Context) error {
@@ -66,7 +115,11 @@ func (s *Server) GetSpecs(ctx echo.Context) error {
 		}, nil)
 		return ctx.JSON(nethttp.StatusInternalServerError, generated.Error{Error: err.Error()})
 	}
-	return ctx.JSON(nethttp.StatusOK, generated.Specs{
+	return ctx.JSON(nethttp.StatusOK, specsResponse(specs))
+}
+
+func specsResponse(specs domain.Specs) generated.Specs {
+	return generated.Specs{
 		Model:       specs.Model,
 		Cores:       int32(specs.Cores),
 		Threads:     int32(specs.Threads),
@@ -75,14 +128,100 @@ func (s *Server) GetSpecs(ctx echo.Context) error {
 		CpuWattage:  specs.CPUWattage,
 		Ram:         specs.RAM,
 		RamSpeed:    specs.RAMSpeed,
-	})
+	}
 }
 
 func (s *Server) GetXmrigStatus(ctx echo.Context) error {
-	status := s.service.XMRigStatus()
+	return ctx.JSON(nethttp.StatusOK, xmrigStatusResponse(s.service.XMRigStatus()))
+}
+
+func (s *Server) StartXmrig(ctx echo.Context) error {
+	return s.controlXmrig(ctx, "start", s.service.StartXMRig)
+}
+
+func (s *Server) StopXmrig(ctx echo.Context) error {
+	return s.controlXmrig(ctx, "stop", s.service.StopXMRig)
+}
+
+func (s *Server) RestartXmrig(ctx echo.Context) error {
+	return s.controlXmrig(ctx, "restart", s.service.RestartXMRig)
+}
+
+func (s *Server) PauseXmrig(ctx echo.Context) error {
+	return s.controlXmrig(ctx, "pause", s.service.PauseXMRig)
+}
+
+func (s *Server) controlXmrig(ctx echo.Context, action string, apply func() error) error {
+	if err := apply(); err != nil {
+		if errors.Is(err, domain.ErrXMRigNotRunning) {
+			return ctx.JSON(nethttp.StatusConflict, generated.Error{Error: err.Error()})
+		}
+		observability.CaptureError(err, map[string]string{
+			"component": "http",
+			"handler":   "xmrig_" + action,
+		}, nil)
+		return ctx.JSON(nethttp.StatusInternalServerError, generated.Error{Error: err.Error()})
+	}
+	return ctx.JSON(nethttp.StatusOK, xmrigStatusResponse(s.service.XMRigStatus()))
+}
+
+func xmrigStatusResponse(status domain.XMRigStatus) generated.XMRigStatus {
 	response := generated.XMRigStatus{
-		Running:    status.Running,
-		HashrateHs: status.HashrateHS,
+		Running:        status.Running,
+		Paused:         status.Paused,
+		DesiredState:   status.DesiredState,
+		StatusSource:   status.StatusSource,
+		HashrateHs:     status.HashrateHS,
+		SharesAccepted: int64(status.SharesAccepted),
+		SharesRejected: int64(status.SharesRejected),
+		SharesStale:    int64(status.SharesStale),
+		RandomxMode:    status.RandomXMode,
+		RestartCount:   int32(status.RestartCount),
+		BackoffMs:      status.BackoffMS,
+		CrashLooping:   status.CrashLooping,
+	}
+	response.Hashrate60sHs = status.Hashrate60sHS
+	response.Hashrate15mHs = status.Hashrate15mHS
+	response.HashrateMaxHs = status.HashrateMaxHS
+	response.LastHashrate15mHs = status.LastHashrate15mHS
+	response.LastHashrate15mTime = status.LastHashrate15mTime
+	if len(status.ThreadsHS) > 0 {
+		threads := status.ThreadsHS
+		response.ThreadsHs = &threads
+	}
+	if status.Pool != "" {
+		pool := status.Pool
+		response.Pool = &pool
+	}
+	if status.UptimeSeconds > 0 {
+		uptime := status.UptimeSeconds
+		response.UptimeSeconds = &uptime
+	}
+	if status.Difficulty > 0 {
+		difficulty := int64(status.Difficulty)
+		response.Difficulty = &difficulty
+	}
+	if status.ShareLatencyMS > 0 {
+		latency := status.ShareLatencyMS
+		response.ShareLatencyMs = &latency
+	}
+	if status.BlockHeight > 0 {
+		height := int64(status.BlockHeight)
+		response.BlockHeight = &height
+	}
+	response.LastJobTime = status.LastJobTime
+	response.NextRestartTime = status.NextRestartTime
+	if status.StopReason != "" {
+		reason := status.StopReason
+		response.StopReason = &reason
+	}
+	if status.ExitCode != nil {
+		code := int32(*status.ExitCode)
+		response.ExitCode = &code
+	}
+	if status.ExitSignal != "" {
+		signal := status.ExitSignal
+		response.ExitSignal = &signal
 	}
 	if status.LastError != "" {
 		errCopy := status.LastError
@@ -91,40 +230,694 @@ func (s *Server) GetXmrigStatus(ctx echo.Context) error {
 	response.LastLogTime = status.LastLogTime
 	response.LastStartTime = status.LastStartTime
 	response.LastExitTime = status.LastExitTime
-	return ctx.JSON(nethttp.StatusOK, response)
+	if status.Thermal != nil {
+		thermal := xmrigThermalResponse(*status.Thermal)
+		response.Thermal = &thermal
+	}
+	if status.Failover != nil {
+		failover := xmrigFailoverResponse(*status.Failover)
+		response.Failover = &failover
+	}
+	if status.Proxy != nil {
+		proxy := generated.XMRigProxy{
+			Address:           status.Proxy.Address,
+			Upstream:          status.Proxy.Upstream,
+			UpstreamConnected: status.Proxy.UpstreamConnected,
+			Miners:            int32(status.Proxy.Miners),
+			Reconnects:        int64(status.Proxy.Reconnects),
+			Jobs:              int64(status.Proxy.Jobs),
+			LastJobTime:       status.Proxy.LastJobTime,
+			SharesSubmitted:   int64(status.Proxy.SharesSubmitted),
+			SharesAccepted:    int64(status.Proxy.SharesAccepted),
+			SharesRejected:    int64(status.Proxy.SharesRejected),
+			LastErrorTime:     status.Proxy.LastErrorTime,
+		}
+		if status.Proxy.LastError != "" {
+			lastError := status.Proxy.LastError
+			proxy.LastError = &lastError
+		}
+		response.Proxy = &proxy
+	}
+	if status.PoolProbe != nil {
+		probe := xmrigPoolProbeResponse(*status.PoolProbe)
+		response.PoolProbe = &probe
+	}
+	if status.Binary != nil {
+		binary := xmrigBinaryResponse(*status.Binary)
+		response.Binary = &binary
+	}
+	if status.Resources != nil {
+		resources := xmrigResourcesResponse(*status.Resources)
+		response.Resources = &resources
+	}
+	if status.RandomX != nil {
+		randomx := xmrigRandomXResponse(*status.RandomX)
+		response.Randomx = &randomx
+	}
+	if status.Benchmark != nil {
+		benchmark := xmrigBenchmarkResponse(*status.Benchmark)
+		response.Benchmark = &benchmark
+	}
+	if status.TuneProfile != nil {
+		profile := xmrigTuneProfileResponse(*status.TuneProfile)
+		response.TuneProfile = &profile
+	}
+	if status.MiningMode != "" {
+		mode := status.MiningMode
+		response.MiningMode = &mode
+	}
+	if status.Idle != nil {
+		response.Idle = &generated.XMRigIdle{
+			ThresholdPercent: status.Idle.ThresholdPercent,
+			BusySeconds:      status.Idle.BusySeconds,
+			IdleSeconds:      status.Idle.IdleSeconds,
+			LoadPercent:      status.Idle.LoadPercent,
+			LoadTime:         status.Idle.LoadTime,
+			Holding:          status.Idle.Holding,
+			HeldSince:        status.Idle.HeldSince,
+			Pauses:           int32(status.Idle.Pauses),
+		}
+	}
+	return response
+}
+
+func xmrigBinaryResponse(binary domain.XMRigBinary) generated.XMRigBinary {
+	response := generated.XMRigBinary{
+		Path:       binary.Path,
+		Version:    binary.Version,
+		Features:   binary.Features,
+		Tls:        binary.TLS,
+		Hwloc:      binary.HWLoc,
+		Pinned:     binary.Pinned,
+		DetectedAt: binary.DetectedAt,
+	}
+	if response.Features == nil {
+		response.Features = []string{}
+	}
+	response.Compiler = optionalString(binary.Compiler)
+	response.BuiltOn = optionalString(binary.BuiltOn)
+	response.Libuv = optionalString(binary.LibUV)
+	response.TlsVersion = optionalString(binary.TLSVersion)
+	response.HwlocVersion = optionalString(binary.HWLocVersion)
+	response.MinVersion = optionalString(binary.MinVersion)
+	response.Sha256 = optionalString(binary.SHA256)
+	return response
+}
+
+// optionalString maps an empty string to an omitted field.
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func xmrigPoolProbeResponse(probe domain.XMRigPoolProbe) generated.XMRigPoolProbe {
+	response := generated.XMRigPoolProbe{
+		Time:                probe.Time,
+		Pool:                probe.Pool,
+		Tls:                 probe.TLS,
+		Addresses:           probe.Addresses,
+		DnsMs:               probe.DNSMS,
+		ConnectMs:           probe.ConnectMS,
+		TlsMs:               probe.TLSMS,
+		Ok:                  probe.OK,
+		ConsecutiveFailures: int32(probe.ConsecutiveFailures),
+		LastSuccessTime:     probe.LastSuccessTime,
+		LastErrorTime:       probe.LastErrorTime,
+	}
+	if response.Addresses == nil {
+		response.Addresses = []string{}
+	}
+	if probe.Stage != "" {
+		stage := probe.Stage
+		response.Stage = &stage
+	}
+	if probe.Error != "" {
+		probeErr := probe.Error
+		response.Error = &probeErr
+	}
+	if probe.LastError != "" {
+		lastError := probe.LastError
+		response.LastError = &lastError
+	}
+	return response
+}
+
+func xmrigFailoverResponse(failover domain.XMRigFailoverStatus) generated.XMRigFailover {
+	response := generated.XMRigFailover{
+		ActivePool:          failover.ActivePool,
+		ActiveIndex:         int32(failover.ActiveIndex),
+		ActiveSince:         failover.ActiveSince,
+		Pools:               failover.Pools,
+		Threshold:           int32(failover.Threshold),
+		Failures:            int32(failover.Failures),
+		RetryPrimarySeconds: failover.RetryPrimarySeconds,
+		NextPrimaryRetry:    failover.NextPrimaryRetry,
+		Switches:            int32(failover.Switches),
+		Events:              make([]generated.XMRigPoolEvent, 0, len(failover.Events)),
+	}
+	if failover.LastError != "" {
+		lastError := failover.LastError
+		response.LastError = &lastError
+	}
+	for _, event := range failover.Events {
+		item := generated.XMRigPoolEvent{
+			Time:     event.Time,
+			From:     event.From,
+			To:       event.To,
+			Reason:   event.Reason,
+			Failures: int32(event.Failures),
+		}
+		if event.Error != "" {
+			eventErr := event.Error
+			item.Error = &eventErr
+		}
+		response.Events = append(response.Events, item)
+	}
+	return response
+}
+
+func xmrigThermalResponse(thermal domain.XMRigThermalStatus) generated.XMRigThermal {
+	response := generated.XMRigThermal{
+		Action:         thermal.Action,
+		TripC:          thermal.TripC,
+		ResumeC:        thermal.ResumeC,
+		MinHoldSeconds: thermal.MinHoldSeconds,
+		TempC:          thermal.TempC,
+		TempTime:       thermal.TempTime,
+		Tripped:        thermal.Tripped,
+		TrippedAt:      thermal.TrippedAt,
+		Interventions:  int32(thermal.Interventions),
+		Events:         make([]generated.XMRigThermalEvent, 0, len(thermal.Events)),
+	}
+	if thermal.ThrottleThreads > 0 {
+		threads := int32(thermal.ThrottleThreads)
+		response.ThrottleThreads = &threads
+	}
+	for _, event := range thermal.Events {
+		item := generated.XMRigThermalEvent{
+			Time:   event.Time,
+			Action: event.Action,
+			TempC:  event.TempC,
+		}
+		if event.Threads > 0 {
+			threads := int32(event.Threads)
+			item.Threads = &threads
+		}
+		response.Events = append(response.Events, item)
+	}
+	return response
 }
 
 func (s *Server) GetXmrigLogs(ctx echo.Context, params generated.GetXmrigLogsParams) error {
-	count, err := xmrigLogCount(params)
+	query, err := xmrigLogQuery(params)
 	if err != nil {
-		return ctx.JSON(nethttp.StatusBadRequest, generated.Error{Error: "invalid n"})
+		return ctx.JSON(nethttp.StatusBadRequest, generated.Error{Error: err.Error()})
+	}
+	page, err := s.service.XMRigLogs(query)
+	if err != nil {
+		if errors.Is(err, domain.ErrXMRigNoLogFile) {
+			return ctx.JSON(nethttp.StatusConflict, generated.Error{Error: err.Error()})
+		}
+		observability.CaptureError(err, map[string]string{
+			"component": "http",
+			"handler":   "xmrig_logs",
+		}, nil)
+		return ctx.JSON(nethttp.StatusInternalServerError, generated.Error{Error: err.Error()})
 	}
-	logs := s.service.XMRigLogs(count)
 	response := generated.XMRigLogs{
-		Count: int32(len(logs)),
-		Logs:  make([]generated.XMRigLogEntry, 0, len(logs)),
+		Count:     int32(len(page.Logs)),
+		Logs:      make([]generated.XMRigLogEntry, 0, len(page.Logs)),
+		OldestSeq: int64(page.OldestSeq),
+		NextSeq:   int64(page.NextSeq),
+		HasMore:   page.HasMore,
+	}
+	for _, entry := range page.Logs {
+		response.Logs = append(response.Logs, xmrigLogEntryResponse(entry))
+	}
+	return ctx.JSON(nethttp.StatusOK, response)
+}
+
+func (s *Server) GetXmrigSchedule(ctx echo.Context) error {
+	sched := s.service.XMRigSchedule()
+	response := generated.XMRigSchedule{
+		Enabled:        sched.Enabled,
+		Mining:         sched.Mining,
+		Args:           sched.Args,
+		ActiveSince:    sched.ActiveSince,
+		ActiveUntil:    sched.ActiveUntil,
+		NextTransition: sched.NextTransition,
+		LastTransition: sched.LastTransition,
+		Windows:        make([]generated.XMRigScheduleWindow, 0, len(sched.Windows)),
+	}
+	if response.Args == nil {
+		response.Args = []string{}
+	}
+	if sched.Timezone != "" {
+		timezone := sched.Timezone
+		response.Timezone = &timezone
+	}
+	if sched.ActiveWindow != "" {
+		window := sched.ActiveWindow
+		response.ActiveWindow = &window
+	}
+	if sched.NextTransition != nil {
+		mining := sched.NextMining
+		response.NextMining = &mining
+		if sched.NextWindow != "" {
+			window := sched.NextWindow
+			response.NextWindow = &window
+		}
+	}
+	for _, window := range sched.Windows {
+		response.Windows = append(response.Windows, generated.XMRigScheduleWindow{
+			Name:            window.Name,
+			Spec:            window.Spec,
+			DurationSeconds: window.DurationSeconds,
+			Mine:            window.Mine,
+			Args:            window.Args,
+		})
+	}
+	return ctx.JSON(nethttp.StatusOK, response)
+}
+
+func (s *Server) GetXmrigHashrateHistory(ctx echo.Context, params generated.GetXmrigHashrateHistoryParams) error {
+	query, err := xmrigHashrateQuery(params, time.Now().UTC())
+	if err != nil {
+		return ctx.JSON(nethttp.StatusBadRequest, generated.Error{Error: err.Error()})
 	}
-	for _, entry := range logs {
-		response.Logs = append(response.Logs, generated.XMRigLogEntry{
-			Time: entry.Time,
-			Line: entry.Line,
+	history := s.service.XMRigHashrateHistory(query)
+	response := generated.XMRigHashrateHistory{
+		From:        history.From,
+		To:          history.To,
+		StepSeconds: int64(history.Step / time.Second),
+		Buckets:     make([]generated.XMRigHashrateBucket, 0, len(history.Buckets)),
+	}
+	for _, bucket := range history.Buckets {
+		response.Buckets = append(response.Buckets, generated.XMRigHashrateBucket{
+			Start:   bucket.Start,
+			Samples: int32(bucket.Samples),
+			MinHs:   bucket.MinHS,
+			MaxHs:   bucket.MaxHS,
+			AvgHs:   bucket.AvgHS,
 		})
 	}
 	return ctx.JSON(nethttp.StatusOK, response)
 }
 
-func xmrigLogCount(params generated.GetXmrigLogsParams) (int, error) {
-	if params.N == nil {
-		return maxXmrigLogs, nil
+func (s *Server) GetXmrigResourceHistory(ctx echo.Context, params generated.GetXmrigResourceHistoryParams) error {
+	var query domain.XMRigResourceQuery
+	if params.Since != nil {
+		query.Since = params.Since.UTC()
+	}
+	if params.Limit != nil {
+		if *params.Limit <= 0 {
+			return ctx.JSON(nethttp.StatusBadRequest, generated.Error{Error: errInvalidResourceLimit.Error()})
+		}
+		query.Limit = *params.Limit
+	}
+	history := s.service.XMRigResourceHistory(query)
+	response := generated.XMRigResourceHistory{
+		IntervalSeconds: int64(history.Interval / time.Second),
+		Samples:         make([]generated.XMRigResources, 0, len(history.Samples)),
+	}
+	for _, sample := range history.Samples {
+		response.Samples = append(response.Samples, xmrigResourcesResponse(sample))
+	}
+	return ctx.JSON(nethttp.StatusOK, response)
+}
+
+func (s *Server) BenchmarkXmrig(ctx echo.Context) error {
+	var body generated.BenchmarkXmrigJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return ctx.JSON(nethttp.StatusBadRequest, generated.Error{Error: err.Error()})
+	}
+	req := domain.XMRigBenchmarkRequest{}
+	if body.Size != nil {
+		req.Size = *body.Size
+	}
+	if body.Config != nil {
+		req.Overrides = xmrigMinerOverrides(*body.Config)
+	}
+	run, err := s.service.BenchmarkXMRig(ctx.Request().Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrXMRigInvalidBenchmarkSize), errors.Is(err, domain.ErrXMRigInvalidBenchmarkConfig):
+			return ctx.JSON(nethttp.StatusBadRequest, generated.Error{Error: err.Error()})
+		case errors.Is(err, domain.ErrXMRigBenchmarkRunning):
+			return ctx.JSON(nethttp.StatusConflict, generated.Error{Error: err.Error()})
+		}
+		observability.CaptureError(err, map[string]string{
+			"component": "http",
+			"handler":   "xmrig_benchmark",
+		}, nil)
+		return ctx.JSON(nethttp.StatusInternalServerError, generated.Error{Error: err.Error()})
+	}
+	return ctx.JSON(nethttp.StatusAccepted, xmrigBenchmarkResponse(run))
+}
+
+func (s *Server) GetXmrigBenchmarks(ctx echo.Context, params generated.GetXmrigBenchmarksParams) error {
+	limit := 0
+	if params.Limit != nil {
+		if *params.Limit <= 0 {
+			return ctx.JSON(nethttp.StatusBadRequest, generated.Error{Error: errInvalidBenchmarkLimit.Error()})
+		}
+		limit = *params.Limit
+	}
+	runs := s.service.XMRigBenchmarks(limit)
+	response := generated.XMRigBenchmarkList{
+		Benchmarks: make([]generated.XMRigBenchmark, 0, len(runs)),
+	}
+	for _, run := range runs {
+		response.Benchmarks = append(response.Benchmarks, xmrigBenchmarkResponse(run))
+	}
+	return ctx.JSON(nethttp.StatusOK, response)
+}
+
+func xmrigMinerOverrides(config generated.XMRigMinerOverrides) *domain.XMRigMinerOverrides {
+	overrides := &domain.XMRigMinerOverrides{
+		HugePages:    config.HugePages,
+		HugePagesJIT: config.HugePagesJit,
+		Yield:        config.Yield,
+		RandomXMode:  config.RandomxMode,
+		OneGBPages:   config.OneGbPages,
+		WrMSR:        config.Wrmsr,
+	}
+	if config.Threads != nil {
+		threads := int(*config.Threads)
+		overrides.Threads = &threads
+	}
+	if config.Affinity != nil {
+		overrides.Affinity = make([]int, 0, len(*config.Affinity))
+		for _, cpu := range *config.Affinity {
+			overrides.Affinity = append(overrides.Affinity, int(cpu))
+		}
+	}
+	if config.Priority != nil {
+		priority := int(*config.Priority)
+		overrides.Priority = &priority
+	}
+	if config.InitThreads != nil {
+		initThreads := int(*config.InitThreads)
+		overrides.InitThreads = &initThreads
+	}
+	return overrides
+}
+
+func xmrigBenchmarkResponse(run domain.XMRigBenchmark) generated.XMRigBenchmark {
+	response := generated.XMRigBenchmark{
+		Id:         run.ID,
+		Size:       run.Size,
+		Status:     run.Status,
+		Proposed:   run.Proposed,
+		StartTime:  run.StartTime,
+		EndTime:    run.EndTime,
+		Algo:       optionalString(run.Algo),
+		Seconds:    run.Seconds,
+		HashrateHs: run.HashrateHS,
+		HashSum:    optionalString(run.HashSum),
+		Config:     xmrigBenchmarkConfigResponse(run.Config),
+		TempStartC: run.TempStartC,
+		TempMaxC:   run.TempMaxC,
+		TempEndC:   run.TempEndC,
+		PowerW:     run.PowerW,
+		Error:      optionalString(run.Error),
+	}
+	if run.Specs != nil {
+		specs := specsResponse(*run.Specs)
+		response.Specs = &specs
+	}
+	return response
+}
+
+func xmrigBenchmarkConfigResponse(config domain.XMRigBenchmarkConfig) generated.XMRigBenchmarkConfig {
+	return generated.XMRigBenchmarkConfig{
+		Threads:      int32(config.Threads),
+		Affinity:     int32Slice(config.Affinity),
+		Priority:     int32(config.Priority),
+		HugePages:    config.HugePages,
+		HugePagesJit: config.HugePagesJIT,
+		Yield:        config.Yield,
+		RandomxMode:  config.RandomXMode,
+		OneGbPages:   config.OneGBPages,
+		InitThreads:  int32(config.InitThreads),
+		Wrmsr:        config.WrMSR,
+		Args:         append([]string{}, config.Args...),
+	}
+}
+
+func (s *Server) TuneXmrig(ctx echo.Context) error {
+	var body generated.TuneXmrigJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return ctx.JSON(nethttp.StatusBadRequest, generated.Error{Error: err.Error()})
+	}
+	req := domain.XMRigTuneRequest{MaxTempC: body.MaxTempC}
+	if body.Objective != nil {
+		req.Objective = *body.Objective
+	}
+	if body.Size != nil {
+		req.Size = *body.Size
+	}
+	if body.Threads != nil {
+		for _, threads := range *body.Threads {
+			req.Threads = append(req.Threads, int(threads))
+		}
+	}
+	if body.RandomxModes != nil {
+		req.RandomXModes = *body.RandomxModes
+	}
+	job, err := s.service.TuneXMRig(ctx.Request().Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrXMRigInvalidTune), errors.Is(err, domain.ErrXMRigInvalidBenchmarkSize):
+			return ctx.JSON(nethttp.StatusBadRequest, generated.Error{Error: err.Error()})
+		case errors.Is(err, domain.ErrXMRigTuneRunning), errors.Is(err, domain.ErrXMRigBenchmarkRunning):
+			return ctx.JSON(nethttp.StatusConflict, generated.Error{Error: err.Error()})
+		}
+		observability.CaptureError(err, map[string]string{
+			"component": "http",
+			"handler":   "xmrig_tune",
+		}, nil)
+		return ctx.JSON(nethttp.StatusInternalServerError, generated.Error{Error: err.Error()})
+	}
+	return ctx.JSON(nethttp.StatusAccepted, xmrigTuneResponse(job))
+}
+
+func (s *Server) GetXmrigTune(ctx echo.Context) error {
+	status := s.service.XMRigTuning()
+	var response generated.XMRigTuneStatus
+	if status.Job != nil {
+		job := xmrigTuneResponse(*status.Job)
+		response.Job = &job
+	}
+	if status.Profile != nil {
+		profile := xmrigTuneProfileResponse(*status.Profile)
+		response.Profile = &profile
+	}
+	return ctx.JSON(nethttp.StatusOK, response)
+}
+
+func xmrigTuneResponse(job domain.XMRigTune) generated.XMRigTune {
+	response := generated.XMRigTune{
+		Id:        job.ID,
+		Status:    job.Status,
+		Objective: job.Objective,
+		Size:      job.Size,
+		MaxTempC:  job.MaxTempC,
+		Stage:     optionalString(job.Stage),
+		StartTime: job.StartTime,
+		EndTime:   job.EndTime,
+		Topology: generated.XMRigCPUTopology{
+			Cpus:     int32(job.Topology.CPUs),
+			Cores:    int32(job.Topology.Cores),
+			Packages: int32(job.Topology.Packages),
+			L3Bytes:  job.Topology.L3Bytes,
+		},
+		Candidates: make([]generated.XMRigTuneCandidate, 0, len(job.Candidates)),
+		Error:      optionalString(job.Error),
 	}
-	count := *params.N
-	if count <= 0 {
-		return 0, errInvalidLogCount
+	for _, candidate := range job.Candidates {
+		response.Candidates = append(response.Candidates, generated.XMRigTuneCandidate{
+			Stage:          candidate.Stage,
+			Name:           candidate.Name,
+			BenchmarkId:    candidate.BenchmarkID,
+			Config:         xmrigBenchmarkConfigResponse(candidate.Config),
+			HashrateHs:     candidate.HashrateHS,
+			PowerW:         candidate.PowerW,
+			HashesPerJoule: candidate.HashesPerJoule,
+			TempMaxC:       candidate.TempMaxC,
+			Eligible:       candidate.Eligible,
+			Skipped:        candidate.Skipped,
+			Reason:         optionalString(candidate.Reason),
+		})
 	}
-	if count > maxXmrigLogs {
-		return maxXmrigLogs, nil
+	if job.Profile != nil {
+		profile := xmrigTuneProfileResponse(*job.Profile)
+		response.Profile = &profile
 	}
-	return count, nil
+	return response
 }
 
-var errInvalidLogCount = errors.New("invalid log count")
+func xmrigTuneProfileResponse(profile domain.XMRigTuneProfile) generated.XMRigTuneProfile {
+	return generated.XMRigTuneProfile{
+		Threads:     int32(profile.Threads),
+		Affinity:    int32Slice(profile.Affinity),
+		RandomxMode: profile.RandomXMode,
+		HugePages:   profile.HugePages,
+		OneGbPages:  profile.OneGBPages,
+		Objective:   profile.Objective,
+		Score:       profile.Score,
+		HashrateHs:  profile.HashrateHS,
+		PowerW:      profile.PowerW,
+		TempMaxC:    profile.TempMaxC,
+		BenchmarkId: profile.BenchmarkID,
+		TunedAt:     profile.TunedAt,
+	}
+}
+
+func int32Slice(values []int) []int32 {
+	converted := make([]int32, 0, len(values))
+	for _, value := range values {
+		converted = append(converted, int32(value))
+	}
+	return converted
+}
+
+func xmrigRandomXResponse(randomx domain.XMRigRandomX) generated.XMRigRandomX {
+	response := generated.XMRigRandomX{
+		Mode:             randomx.Mode,
+		HugePagesPercent: randomx.HugePagesPercent,
+		Initializing:     randomx.Initializing,
+		DatasetReady:     randomx.DatasetReady,
+		DatasetInitMs:    randomx.DatasetInitMS,
+		DatasetReadyTime: randomx.DatasetReadyTime,
+		Degraded:         randomx.Degraded,
+		DegradedReasons:  randomx.DegradedReasons,
+	}
+	if response.DegradedReasons == nil {
+		response.DegradedReasons = []string{}
+	}
+	response.HugePages = optionalString(randomx.HugePages)
+	response.OneGbPages = optionalString(randomx.OneGBPages)
+	response.Msr = optionalString(randomx.MSR)
+	response.MsrPreset = optionalString(randomx.MSRPreset)
+	response.MsrError = optionalString(randomx.MSRError)
+	response.Algo = optionalString(randomx.Algo)
+	if randomx.HugePagesPercent != nil {
+		allocated := int32(randomx.HugePagesAllocated)
+		total := int32(randomx.HugePagesTotal)
+		datasetMB := int32(randomx.DatasetMB)
+		response.HugePagesAllocated = &allocated
+		response.HugePagesTotal = &total
+		response.DatasetMb = &datasetMB
+	}
+	return response
+}
+
+func xmrigResourcesResponse(sample domain.XMRigResources) generated.XMRigResources {
+	response := generated.XMRigResources{
+		Time:                   sample.Time,
+		Pid:                    int32(sample.PID),
+		CpuPercent:             sample.CPUPercent,
+		UserSeconds:            sample.UserSeconds,
+		SystemSeconds:          sample.SystemSeconds,
+		RssBytes:               int64(sample.RSSBytes),
+		PeakRssBytes:           int64(sample.PeakRSSBytes),
+		Threads:                int32(sample.Threads),
+		VoluntaryCtxSwitches:   int64(sample.VoluntaryCtxSwitches),
+		InvoluntaryCtxSwitches: int64(sample.InvoluntaryCtxSwitches),
+		Nice:                   int32(sample.Nice),
+	}
+	if sample.ReadBytes != nil {
+		read := int64(*sample.ReadBytes)
+		response.ReadBytes = &read
+	}
+	if sample.WriteBytes != nil {
+		write := int64(*sample.WriteBytes)
+		response.WriteBytes = &write
+	}
+	return response
+}
+
+func xmrigHashrateQuery(params generated.GetXmrigHashrateHistoryParams, now time.Time) (domain.XMRigHashrateQuery, error) {
+	query := domain.XMRigHashrateQuery{To: now}
+	if params.To != nil {
+		query.To = params.To.UTC()
+	}
+	query.From = query.To.Add(-defaultHashrateRange)
+	if params.From != nil {
+		query.From = params.From.UTC()
+	}
+	if !query.To.After(query.From) {
+		return query, errInvalidHashrateRange
+	}
+	if params.Step != nil {
+		step, err := time.ParseDuration(*params.Step)
+		if err != nil || step < time.Second {
+			return query, errInvalidHashrateStep
+		}
+		query.Step = step.Truncate(time.Second)
+	} else {
+		query.Step = (query.To.Sub(query.From) / defaultHashrateBuckets).Truncate(time.Second)
+		if query.Step < time.Second {
+			query.Step = time.Second
+		}
+	}
+	if query.To.Sub(query.From)/query.Step >= maxHashrateBuckets {
+		return query, errTooManyHashrateBuckets
+	}
+	return query, nil
+}
+
+func xmrigLogEntryResponse(entry domain.XMRigLogEntry) generated.XMRigLogEntry {
+	return generated.XMRigLogEntry{
+		Seq:    int64(entry.Seq),
+		Time:   entry.Time,
+		Stream: entry.Stream,
+		Line:   entry.Line,
+	}
+}
+
+func xmrigLogQuery(params generated.GetXmrigLogsParams) (domain.XMRigLogQuery, error) {
+	query := domain.XMRigLogQuery{
+		Limit: defaultXmrigLogs,
+		Since: params.Since,
+		Until: params.Until,
+	}
+	if params.History != nil {
+		query.History = *params.History
+	}
+	if params.N != nil {
+		if *params.N <= 0 {
+			return query, errInvalidLogCount
+		}
+		query.Limit = *params.N
+		if query.Limit > maxXmrigLogs {
+			query.Limit = maxXmrigLogs
+		}
+	}
+	if params.SinceSeq != nil {
+		if *params.SinceSeq < 0 {
+			return query, errInvalidLogCursor
+		}
+		query.SinceSeq = uint64(*params.SinceSeq)
+	}
+	if query.Since != nil && query.Until != nil && !query.Until.After(*query.Since) {
+		return query, errInvalidLogRange
+	}
+	return query, nil
+}
+
+var (
+	errInvalidLogCount  = errors.New("invalid n")
+	errInvalidLogCursor = errors.New("invalid since_seq")
+	errInvalidLogRange  = errors.New("until must be after since")
+
+	errInvalidHashrateRange   = errors.New("to must be after from")
+	errInvalidHashrateStep    = errors.New("invalid step, expected a duration of at least 1s")
+	errTooManyHashrateBuckets = errors.New("step is too small for the requested range")
+
+	errInvalidResourceLimit  = errors.New("limit must be positive")
+	errInvalidBenchmarkLimit = errors.New("limit must be positive")
+)
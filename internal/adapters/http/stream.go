@@ -0,0 +1,85 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	nethttp "net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/restartfu/grid-node/internal/domain"
+	"github.com/restartfu/grid-node/openapi/generated"
+)
+
+// sseKeepAlive keeps idle streams from being closed by proxies.
+const sseKeepAlive = 15 * time.Second
+
+func (s *Server) StreamXmrigLogs(ctx echo.Context, params generated.StreamXmrigLogsParams) error {
+	backlog := 0
+	if params.Backlog != nil {
+		backlog = *params.Backlog
+		if backlog < 0 {
+			return ctx.JSON(nethttp.StatusBadRequest, generated.Error{Error: "invalid backlog"})
+		}
+		if backlog > maxXmrigLogs {
+			backlog = maxXmrigLogs
+		}
+	}
+
+	logs, updates, cancel := s.service.XMRigLogStream(backlog)
+	defer cancel()
+
+	response := ctx.Response()
+	response.Header().Set(echo.HeaderContentType, "text/event-stream")
+	response.Header().Set("Cache-Control", "no-cache")
+	response.Header().Set("Connection", "keep-alive")
+	response.Header().Set("X-Accel-Buffering", "no")
+	response.WriteHeader(nethttp.StatusOK)
+
+	for _, entry := range logs {
+		if err := writeLogEvent(response, entry); err != nil {
+			return nil
+		}
+	}
+	response.Flush()
+
+	keepAlive := time.NewTicker(sseKeepAlive)
+	defer keepAlive.Stop()
+	done := ctx.Request().Context().Done()
+	for {
+		select {
+		case <-done:
+			return nil
+		case <-s.streamsDone:
+			return nil
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(response, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+		case event, ok := <-updates:
+			if !ok {
+				_, _ = fmt.Fprint(response, "event: dropped\ndata: {\"error\":\"client too slow\"}\n\n")
+				response.Flush()
+				return nil
+			}
+			line, isLog := event.(domain.XMRigLogEvent)
+			if !isLog {
+				continue
+			}
+			if err := writeLogEvent(response, line.Entry); err != nil {
+				return nil
+			}
+		}
+		response.Flush()
+	}
+}
+
+func writeLogEvent(response *echo.Response, entry domain.XMRigLogEntry) error {
+	data, err := json.Marshal(xmrigLogEntryResponse(entry))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(response, "id: %d\nevent: log\ndata: %s\n\n", entry.Seq, data)
+	return err
+}
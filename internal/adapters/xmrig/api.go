@@ -0,0 +1,129 @@
+package xmrig
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const apiHost = "127.0.0.1"
+
+const apiRequestTimeout = 2 * time.Second
+
+// apiSummary mirrors the parts of xmrig's /2/summary response grid-node uses.
+type apiSummary struct {
+	Uptime     int64         `json:"uptime"`
+	Algo       string        `json:"algo"`
+	Paused     bool          `json:"paused"`
+	Results    apiResults    `json:"results"`
+	Connection apiConnection `json:"connection"`
+	Hashrate   apiHashrate   `json:"hashrate"`
+}
+
+type apiResults struct {
+	DiffCurrent uint64 `json:"diff_current"`
+	SharesGood  uint64 `json:"shares_good"`
+	SharesTotal uint64 `json:"shares_total"`
+}
+
+type apiConnection struct {
+	Pool     string `json:"pool"`
+	Uptime   int64  `json:"uptime"`
+	Ping     int64  `json:"ping"`
+	Failures int64  `json:"failures"`
+	Diff     uint64 `json:"diff"`
+	Accepted uint64 `json:"accepted"`
+	Rejected uint64 `json:"rejected"`
+}
+
+// apiHashrate holds 10s/60s/15m triples; xmrig reports null until a window fills.
+type apiHashrate struct {
+	Total   []*float64   `json:"total"`
+	Highest *float64     `json:"highest"`
+	Threads [][]*float64 `json:"threads"`
+}
+
+type apiClient struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+func newAPIClient(baseURL, token string) *apiClient {
+	return &apiClient{
+		baseURL: baseURL,
+		token:   token,
+		client:  &http.Client{Timeout: apiRequestTimeout},
+	}
+}
+
+func (c *apiClient) summary(ctx context.Context) (apiSummary, error) {
+	var summary apiSummary
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/2/summary", nil)
+	if err != nil {
+		return summary, err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return summary, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return summary, fmt.Errorf("xmrig api: unexpected status %s", resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&summary); err != nil {
+		return summary, fmt.Errorf("xmrig api: decode summary: %w", err)
+	}
+	return summary, nil
+}
+
+// apiEndpoint is the localhost API a single xmrig launch is bound to.
+type apiEndpoint struct {
+	port  int
+	token string
+}
+
+func newAPIEndpoint(port int) (apiEndpoint, error) {
+	if port == 0 {
+		free, err := freeLocalPort()
+		if err != nil {
+			return apiEndpoint{}, err
+		}
+		port = free
+	}
+	token, err := randomToken()
+	if err != nil {
+		return apiEndpoint{}, err
+	}
+	return apiEndpoint{port: port, token: token}, nil
+}
+
+func (e apiEndpoint) client() *apiClient {
+	return newAPIClient("http://"+net.JoinHostPort(apiHost, strconv.Itoa(e.port)), e.token)
+}
+
+func freeLocalPort() (int, error) {
+	listener, err := net.Listen("tcp", net.JoinHostPort(apiHost, "0"))
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+func randomToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
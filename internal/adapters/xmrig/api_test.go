@@ -0,0 +1,156 @@
+package xmrig
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/restartfu/grid-node/internal/domain"
+)
+
+const summaryFixture = `{
+	"uptime": 120,
+	"algo": "rx/0",
+	"paused": false,
+	"results": {"diff_current": 120001, "shares_good": 3, "shares_total": 4},
+	"connection": {"pool": "tokyo:3333", "uptime": 100, "ping": 45, "failures": 0, "diff": 120001, "accepted": 3, "rejected": 1},
+	"hashrate": {
+		"total": [2000.5, 1990.0, null],
+		"highest": 2100.0,
+		"threads": [[1000.25, null, null], [1000.25, null, null]]
+	}
+}`
+
+func newPollingWrapper(t *testing.T) *Wrapper {
+	t.Helper()
+	r := NewWrapper(io.Discard, Config{APIPollInterval: 10 * time.Millisecond})
+	r.state.recordStart(time.Now().UTC(), 1)
+	return r
+}
+
+// pollUntil runs pollAPI against client until done reports true or a second
+// passed.
+func pollUntil(t *testing.T, r *Wrapper, client *apiClient, done func(domain.XMRigStatus) bool) domain.XMRigStatus {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		r.pollAPI(ctx, client, 1)
+	}()
+	defer func() {
+		cancel()
+		<-finished
+	}()
+	deadline := time.Now().Add(time.Second)
+	for {
+		status := r.Status()
+		if done(status) || time.Now().After(deadline) {
+			return status
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestPollAPISummary(t *testing.T) {
+	const token = "secret"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/2/summary" {
+			http.NotFound(w, req)
+			return
+		}
+		if req.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, summaryFixture)
+	}))
+	defer server.Close()
+
+	t.Run("valid token", func(t *testing.T) {
+		r := newPollingWrapper(t)
+		status := pollUntil(t, r, newAPIClient(server.URL, token), func(status domain.XMRigStatus) bool {
+			return status.StatusSource == domain.XMRigSourceAPI
+		})
+		if status.StatusSource != domain.XMRigSourceAPI {
+			t.Fatalf("status source = %q, want %q", status.StatusSource, domain.XMRigSourceAPI)
+		}
+		if status.HashrateHS != 2000.5 {
+			t.Errorf("hashrate = %v, want 2000.5", status.HashrateHS)
+		}
+		if status.Hashrate60sHS == nil || *status.Hashrate60sHS != 1990 {
+			t.Errorf("60s hashrate = %v, want 1990", status.Hashrate60sHS)
+		}
+		if status.Hashrate15mHS != nil {
+			t.Errorf("15m hashrate = %v, want nil while xmrig reports null", *status.Hashrate15mHS)
+		}
+		if len(status.ThreadsHS) != 2 || status.ThreadsHS[0] != 1000.25 || status.ThreadsHS[1] != 1000.25 {
+			t.Errorf("threads = %v, want [1000.25 1000.25]", status.ThreadsHS)
+		}
+		if status.Pool != "tokyo:3333" {
+			t.Errorf("pool = %q, want tokyo:3333", status.Pool)
+		}
+		if status.UptimeSeconds != 120 {
+			t.Errorf("uptime = %d, want 120", status.UptimeSeconds)
+		}
+		if status.Difficulty != 120001 {
+			t.Errorf("difficulty = %d, want 120001", status.Difficulty)
+		}
+		if status.SharesAccepted != 3 || status.SharesRejected != 1 {
+			t.Errorf("shares = %d/%d, want 3/1", status.SharesAccepted, status.SharesRejected)
+		}
+	})
+
+	t.Run("wrong token", func(t *testing.T) {
+		if _, err := newAPIClient(server.URL, "wrong").summary(context.Background()); err == nil {
+			t.Fatal("summary with a wrong token succeeded")
+		}
+	})
+}
+
+func TestPollAPIFailureFallsBackToLog(t *testing.T) {
+	healthy := make(chan bool, 1)
+	healthy <- true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ok := <-healthy
+		healthy <- ok
+		if !ok {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(w, summaryFixture)
+	}))
+	defer server.Close()
+
+	r := newPollingWrapper(t)
+	client := newAPIClient(server.URL, "")
+	status := pollUntil(t, r, client, func(status domain.XMRigStatus) bool {
+		return status.StatusSource == domain.XMRigSourceAPI
+	})
+	if status.StatusSource != domain.XMRigSourceAPI {
+		t.Fatalf("status source = %q, want %q", status.StatusSource, domain.XMRigSourceAPI)
+	}
+
+	<-healthy
+	healthy <- false
+	status = pollUntil(t, r, client, func(status domain.XMRigStatus) bool {
+		return status.StatusSource == domain.XMRigSourceLog
+	})
+	if status.StatusSource != domain.XMRigSourceLog {
+		t.Fatalf("status source = %q after a 503, want %q", status.StatusSource, domain.XMRigSourceLog)
+	}
+	if len(status.ThreadsHS) != 0 {
+		t.Errorf("threads = %v, want none without the API", status.ThreadsHS)
+	}
+
+	line := "[2024-01-01 00:00:00.000]  miner    speed 10s/60s/15m 1500.5 1480.0 n/a H/s max 1600.0 H/s"
+	r.state.recordLine(line, domain.XMRigStreamStdout, time.Now().UTC(), parseLine(line), 1)
+	status = r.Status()
+	if status.HashrateHS != 1500.5 {
+		t.Errorf("hashrate = %v after a log line, want 1500.5", status.HashrateHS)
+	}
+}
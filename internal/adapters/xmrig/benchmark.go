@@ -0,0 +1,543 @@
+package xmrig
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/restartfu/grid-node/internal/domain"
+	"github.com/restartfu/grid-node/internal/observability"
+)
+
+// benchmarkTempInterval is how often the CPU temperature, and at most how
+// often the package power, is read during a benchmark.
+const benchmarkTempInterval = 5 * time.Second
+
+// stopBenchmarkTimeout is the internal stop reason for a benchmark that ran
+// past BenchmarkTimeout.
+const stopBenchmarkTimeout = "benchmark_timeout"
+
+// stopBenchmarkHold is the internal stop reason for a benchmark cut short by
+// a hold or a thermal throttle.
+const stopBenchmarkHold = "benchmark_hold"
+
+var benchStartRegex = regexp.MustCompile(`\bstart benchmark hashes (\S+) algo (\S+)`)
+
+var benchDoneRegex = regexp.MustCompile(`\bbenchmark finished in ([\d.]+) seconds \(([\d.]+) h/s\) hash sum = ([0-9A-Fa-f]+)`)
+
+var errBenchmarkStopped = errors.New("benchmark stopped")
+
+// errBenchmarkHeld marks a run whose result a hold or throttle would skew.
+var errBenchmarkHeld = errors.New("benchmark held")
+
+// benchmarkRun is a queued or running benchmark. done is closed once the
+// result is stored.
+type benchmarkRun struct {
+	mu        sync.Mutex
+	result    domain.XMRigBenchmark
+	overrides *domain.XMRigMinerOverrides
+	stderr    []string
+	// heldBy names the hold or throttle that cut the run short.
+	heldBy string
+	// deadline, when set, stops the run before BenchmarkTimeout would; an
+	// auto-tune sets it to the end of the sweep.
+	deadline time.Time
+	done     chan struct{}
+}
+
+func (b *benchmarkRun) update(fn func(*domain.XMRigBenchmark)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	fn(&b.result)
+}
+
+func (b *benchmarkRun) snapshot() domain.XMRigBenchmark {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return copyBenchmark(b.result)
+}
+
+// Benchmark queues an xmrig --bench run. Regular mining stops until it is
+// done and then resumes if the operator still wants xmrig running.
+func (r *Wrapper) Benchmark(req domain.XMRigBenchmarkRequest) (domain.XMRigBenchmark, error) {
+	run, err := r.queueBenchmark(req)
+	if err != nil {
+		return domain.XMRigBenchmark{}, err
+	}
+	return run.snapshot(), nil
+}
+
+// Benchmarks lists stored runs, newest first, after the one in progress.
+func (r *Wrapper) Benchmarks(limit int) []domain.XMRigBenchmark {
+	runs := []domain.XMRigBenchmark{}
+	if run := r.pendingBenchmark(); run != nil {
+		runs = append(runs, run.snapshot())
+	}
+	runs = append(runs, r.benchmarks.list()...)
+	if limit > 0 && len(runs) > limit {
+		runs = runs[:limit]
+	}
+	return runs
+}
+
+func (r *Wrapper) queueBenchmark(req domain.XMRigBenchmarkRequest) (*benchmarkRun, error) {
+	if req.Size == "" {
+		req.Size = domain.XMRigBenchmark1M
+	}
+	if !validBenchmarkSize(req.Size) {
+		return nil, domain.ErrXMRigInvalidBenchmarkSize
+	}
+	miner := applyOverrides(r.baseMiner(), req.Overrides)
+	if err := miner.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrXMRigInvalidBenchmarkConfig, err)
+	}
+	run, err := newBenchmarkRun(req, miner)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	if r.tune != nil {
+		r.mu.Unlock()
+		return nil, domain.ErrXMRigTuneRunning
+	}
+	if r.benchmark != nil {
+		r.mu.Unlock()
+		return nil, domain.ErrXMRigBenchmarkRunning
+	}
+	r.benchmark = run
+	r.mu.Unlock()
+	r.stopProcess(domain.XMRigStopBenchmark)
+	r.notify()
+	return run, nil
+}
+
+func newBenchmarkRun(req domain.XMRigBenchmarkRequest, miner MinerConfig) (*benchmarkRun, error) {
+	id, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	return &benchmarkRun{
+		result: domain.XMRigBenchmark{
+			ID:        id[:12],
+			Size:      req.Size,
+			Status:    domain.XMRigBenchmarkPending,
+			Proposed:  req.Overrides != nil,
+			StartTime: time.Now().UTC(),
+			// Refined with the thread limit and args once the run starts.
+			Config: benchmarkConfig(miner, nil),
+			Specs:  req.Specs,
+		},
+		overrides: req.Overrides,
+		done:      make(chan struct{}),
+	}, nil
+}
+
+func validBenchmarkSize(size string) bool {
+	return size == domain.XMRigBenchmark1M || size == domain.XMRigBenchmark10M
+}
+
+func (r *Wrapper) pendingBenchmark() *benchmarkRun {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.benchmark
+}
+
+// runBenchmark executes a queued run in place of regular mining and stores
+// the result.
+func (r *Wrapper) runBenchmark(ctx context.Context, run *benchmarkRun) error {
+	err := r.executeBenchmark(ctx, run)
+	now := time.Now().UTC()
+	run.update(func(result *domain.XMRigBenchmark) {
+		result.EndTime = &now
+		result.Status = domain.XMRigBenchmarkDone
+		switch {
+		case errors.Is(err, errBenchmarkHeld):
+			result.Status = domain.XMRigBenchmarkInvalid
+			result.Error = err.Error()
+		case err != nil:
+			result.Status = domain.XMRigBenchmarkFailed
+			result.Error = err.Error()
+		}
+	})
+	result := run.snapshot()
+	if err == nil {
+		log.Printf("xmrig benchmark %s: %s in %.1fs, %.1f H/s", result.ID, result.Size, *result.Seconds, *result.HashrateHS)
+	} else {
+		log.Printf("xmrig benchmark %s: %v", result.ID, err)
+	}
+	// Runs stopped by the operator, the schedule, a hold or a shutdown are
+	// not errors.
+	if err != nil && !errors.Is(err, errBenchmarkStopped) && !errors.Is(err, errBenchmarkHeld) {
+		observability.CaptureError(err, map[string]string{
+			"component": "xmrig",
+			"operation": "benchmark",
+		}, map[string]interface{}{
+			"id":       result.ID,
+			"size":     result.Size,
+			"proposed": result.Proposed,
+		})
+	}
+	r.benchmarks.add(result)
+
+	r.mu.Lock()
+	r.benchmark = nil
+	r.mu.Unlock()
+	close(run.done)
+	return err
+}
+
+// executeBenchmark runs xmrig --bench. It refuses to start while a hold or
+// thermal throttle is active and is stopped when one begins, since a paused or
+// throttled run would store a misleading hashrate.
+func (r *Wrapper) executeBenchmark(ctx context.Context, run *benchmarkRun) error {
+	if held := r.activeHold(); held != "" {
+		return fmt.Errorf("%w: not started during the %s", errBenchmarkHeld, held)
+	}
+	path, refused := r.prepareBinary(ctx)
+	if refused != nil {
+		return refused.err
+	}
+	miner := applyOverrides(r.baseMiner(), run.overrides)
+	if err := miner.Validate(); err != nil {
+		return err
+	}
+	data, err := miner.render(nil)
+	if err != nil {
+		return err
+	}
+	configPath := benchmarkConfigPath(r.config.ConfigPath)
+	if err := writeMinerConfig(configPath, data); err != nil {
+		return fmt.Errorf("write %s: %w", configPath, err)
+	}
+	var extra []string
+	if run.overrides == nil {
+		extra = append(append(extra, r.config.ExtraArgs...), r.scheduleLaunchArgs()...)
+	}
+	args := append([]string{"--config=" + configPath, "--bench=" + run.snapshot().Size}, extra...)
+	run.update(func(result *domain.XMRigBenchmark) {
+		result.Status = domain.XMRigBenchmarkRunning
+		result.StartTime = time.Now().UTC()
+		result.Config = benchmarkConfig(miner, extra)
+		result.TempStartC = r.readTempPointer()
+	})
+
+	cmd := newProcessGroup(path, args)
+	pipes, err := attachOutput(cmd)
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		pipes.closeWriters()
+		pipes.closeReaders()
+		return err
+	}
+	pipes.closeWriters()
+
+	procCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	exited := make(chan struct{})
+	go terminateOnCancel(procCtx, cmd, r.config.StopGracePeriod, exited)
+	r.setProcess(&process{cmd: cmd, cancel: cancel})
+	// A hold that began while xmrig was starting found no process to stop.
+	if held := r.activeHold(); held != "" {
+		r.interruptBenchmark(held)
+	}
+	limit, limitReason := r.config.BenchmarkTimeout, stopBenchmarkTimeout
+	if !run.deadline.IsZero() && time.Until(run.deadline) < limit {
+		limit, limitReason = time.Until(run.deadline), stopTuneTimeout
+	}
+	timeout := time.AfterFunc(limit, func() {
+		r.stopProcess(limitReason)
+	})
+	go r.watchBenchmarkTemp(procCtx, run)
+	go r.watchBenchmarkPower(procCtx, run)
+
+	var streams sync.WaitGroup
+	streams.Add(2)
+	go func() {
+		defer streams.Done()
+		r.streamBenchmarkLogs(pipes.stdout, domain.XMRigStreamStdout, run, cancel)
+	}()
+	go func() {
+		defer streams.Done()
+		r.streamBenchmarkLogs(pipes.stderr, domain.XMRigStreamStderr, run, cancel)
+	}()
+	waitErr := cmd.Wait()
+	close(exited)
+	killProcessGroup(cmd)
+	streams.Wait()
+	pipes.closeReaders()
+	timeout.Stop()
+	reason := r.takeProcess().reason
+
+	finished := run.snapshot().HashrateHS != nil
+	run.update(func(result *domain.XMRigBenchmark) {
+		result.TempEndC = r.readTempPointer()
+	})
+	run.mu.Lock()
+	heldBy := run.heldBy
+	run.mu.Unlock()
+	switch {
+	case finished:
+		return nil
+	case ctx.Err() != nil:
+		return fmt.Errorf("%w by %s", errBenchmarkStopped, domain.XMRigStopShutdown)
+	case heldBy != "":
+		return fmt.Errorf("%w: stopped by the %s", errBenchmarkHeld, heldBy)
+	case reason == domain.XMRigStopThermal:
+		return fmt.Errorf("%w: stopped by the thermal guard", errBenchmarkHeld)
+	case reason == stopBenchmarkTimeout:
+		return fmt.Errorf("no result within %s", r.config.BenchmarkTimeout)
+	case reason != "":
+		return fmt.Errorf("%w by %s", errBenchmarkStopped, reason)
+	}
+	run.mu.Lock()
+	stderr := strings.Join(run.stderr, "; ")
+	run.mu.Unlock()
+	if waitErr == nil {
+		waitErr = errors.New("xmrig exited without a benchmark result")
+	}
+	if stderr != "" {
+		return fmt.Errorf("%v: %s", waitErr, stderr)
+	}
+	return waitErr
+}
+
+// activeHold names the hold or thermal throttle currently limiting xmrig, or
+// returns "".
+func (r *Wrapper) activeHold() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.threadLimit > 0 {
+		return "thermal throttle"
+	}
+	for _, reason := range []string{holdThermal, holdIdle} {
+		if _, ok := r.holds[reason]; ok {
+			return reason + " hold"
+		}
+	}
+	return ""
+}
+
+// interruptBenchmark stops a running benchmark because of held, a hold or
+// throttle that would skew its result.
+func (r *Wrapper) interruptBenchmark(held string) {
+	run := r.pendingBenchmark()
+	if run == nil || run.snapshot().Status != domain.XMRigBenchmarkRunning {
+		return
+	}
+	run.mu.Lock()
+	if run.heldBy == "" {
+		run.heldBy = held
+	}
+	run.mu.Unlock()
+	r.stopProcess(stopBenchmarkHold)
+}
+
+// streamBenchmarkLogs records benchmark output in the log buffer without
+// feeding it to the mining status, and stops xmrig once the result is in.
+func (r *Wrapper) streamBenchmarkLogs(reader io.Reader, stream string, run *benchmarkRun, stop func()) {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if r.output != nil {
+			_, _ = fmt.Fprintln(r.output, line)
+		}
+		r.state.recordLine(line, stream, time.Now().UTC(), lineInfo{}, 0)
+		clean := ansiRegex.ReplaceAllString(line, "")
+		if stream == domain.XMRigStreamStderr {
+			run.mu.Lock()
+			run.stderr = append(run.stderr, clean)
+			if len(run.stderr) > maxStderrTail {
+				run.stderr = run.stderr[len(run.stderr)-maxStderrTail:]
+			}
+			run.mu.Unlock()
+		}
+		if match := benchStartRegex.FindStringSubmatch(clean); match != nil {
+			run.update(func(result *domain.XMRigBenchmark) {
+				result.Algo = match[2]
+			})
+		}
+		if match := benchDoneRegex.FindStringSubmatch(clean); match != nil {
+			seconds, _ := strconv.ParseFloat(match[1], 64)
+			hashrate, _ := strconv.ParseFloat(match[2], 64)
+			run.update(func(result *domain.XMRigBenchmark) {
+				result.Seconds = &seconds
+				result.HashrateHS = &hashrate
+				result.HashSum = strings.ToUpper(match[3])
+			})
+			// xmrig keeps running after an offline benchmark.
+			stop()
+		}
+	}
+}
+
+func (r *Wrapper) watchBenchmarkTemp(ctx context.Context, run *benchmarkRun) {
+	ticker := time.NewTicker(benchmarkTempInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		temp := r.readTempPointer()
+		if temp == nil {
+			continue
+		}
+		run.update(func(result *domain.XMRigBenchmark) {
+			if result.TempMaxC == nil || *temp > *result.TempMaxC {
+				result.TempMaxC = temp
+			}
+		})
+	}
+}
+
+// watchBenchmarkPower averages the CPU package power over the run. turbostat
+// already blocks for its own interval, so the next reading only waits for
+// whatever is left of benchmarkTempInterval; a failed reading is retried the
+// same way. A reading that overlaps the end of the run is dropped.
+func (r *Wrapper) watchBenchmarkPower(ctx context.Context, run *benchmarkRun) {
+	var total float64
+	var samples int
+	for {
+		started := time.Now()
+		watts, ok := r.readPower()
+		if ctx.Err() != nil {
+			return
+		}
+		if ok {
+			total += watts
+			samples++
+			average := total / float64(samples)
+			run.update(func(result *domain.XMRigBenchmark) {
+				result.PowerW = &average
+			})
+		}
+		if wait := benchmarkTempInterval - time.Since(started); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+func (r *Wrapper) readTempPointer() *float64 {
+	temp, ok := r.readTemp()
+	if !ok {
+		return nil
+	}
+	return &temp
+}
+
+// applyOverrides returns the config with the proposed settings. Overriding
+// the thread count drops a configured affinity of a different length.
+func applyOverrides(miner MinerConfig, overrides *domain.XMRigMinerOverrides) MinerConfig {
+	miner.CPU.Affinity = append([]int(nil), miner.CPU.Affinity...)
+	if overrides == nil {
+		return miner
+	}
+	if overrides.Threads != nil {
+		miner.CPU.Threads = *overrides.Threads
+		miner.CPU.MaxThreadsHint = 0
+		if len(miner.CPU.Affinity) != miner.CPU.Threads {
+			miner.CPU.Affinity = nil
+		}
+	}
+	if overrides.Affinity != nil {
+		miner.CPU.Affinity = append([]int(nil), overrides.Affinity...)
+		miner.CPU.MaxThreadsHint = 0
+	}
+	if overrides.Priority != nil {
+		miner.CPU.Priority = *overrides.Priority
+	}
+	if overrides.HugePages != nil {
+		miner.CPU.HugePages = *overrides.HugePages
+		if !miner.CPU.HugePages {
+			miner.CPU.HugePagesJIT = false
+			miner.RandomX.OneGBPages = false
+		}
+	}
+	if overrides.HugePagesJIT != nil {
+		miner.CPU.HugePagesJIT = *overrides.HugePagesJIT
+	}
+	if overrides.Yield != nil {
+		miner.CPU.Yield = *overrides.Yield
+	}
+	if overrides.RandomXMode != nil {
+		miner.RandomX.Mode = *overrides.RandomXMode
+		if miner.RandomX.Mode == "light" {
+			miner.RandomX.OneGBPages = false
+		}
+	}
+	if overrides.OneGBPages != nil {
+		miner.RandomX.OneGBPages = *overrides.OneGBPages
+	}
+	if overrides.InitThreads != nil {
+		miner.RandomX.InitThreads = *overrides.InitThreads
+	}
+	if overrides.WrMSR != nil {
+		miner.RandomX.WrMSR = *overrides.WrMSR
+	}
+	return miner
+}
+
+func benchmarkConfig(miner MinerConfig, args []string) domain.XMRigBenchmarkConfig {
+	return domain.XMRigBenchmarkConfig{
+		Threads:      miner.CPU.Threads,
+		Affinity:     append([]int(nil), miner.CPU.Affinity...),
+		Priority:     miner.CPU.Priority,
+		HugePages:    miner.CPU.HugePages,
+		HugePagesJIT: miner.CPU.HugePagesJIT,
+		Yield:        miner.CPU.Yield,
+		RandomXMode:  miner.RandomX.Mode,
+		OneGBPages:   miner.RandomX.OneGBPages,
+		InitThreads:  miner.RandomX.InitThreads,
+		WrMSR:        miner.RandomX.WrMSR,
+		Args:         append([]string(nil), args...),
+	}
+}
+
+// benchmarkConfigPath keeps the benchmark config next to the mining one so
+// a running benchmark never rewrites it.
+func benchmarkConfigPath(configPath string) string {
+	return strings.TrimSuffix(configPath, ".json") + "-bench.json"
+}
+
+func copyBenchmark(result domain.XMRigBenchmark) domain.XMRigBenchmark {
+	result.EndTime = copyTime(result.EndTime)
+	result.Seconds = copyFloat(result.Seconds)
+	result.HashrateHS = copyFloat(result.HashrateHS)
+	result.TempStartC = copyFloat(result.TempStartC)
+	result.TempMaxC = copyFloat(result.TempMaxC)
+	result.TempEndC = copyFloat(result.TempEndC)
+	result.PowerW = copyFloat(result.PowerW)
+	result.Config.Affinity = append([]int(nil), result.Config.Affinity...)
+	result.Config.Args = append([]string(nil), result.Config.Args...)
+	if result.Specs != nil {
+		specs := *result.Specs
+		result.Specs = &specs
+	}
+	return result
+}
+
+func copyTime(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
@@ -0,0 +1,155 @@
+package xmrig
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/restartfu/grid-node/internal/domain"
+)
+
+func TestStreamBenchmarkLogs(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    []string
+		algo     string
+		seconds  float64
+		hashrate float64
+		hashSum  string
+		stopped  bool
+	}{
+		{
+			name: "plain",
+			lines: []string{
+				"[2024-01-01 10:02:41.432]  bench    start benchmark hashes 1M algo rx/0",
+				"[2024-01-01 10:03:27.146]  bench    benchmark finished in 45.711 seconds (21876.4 h/s) hash sum = 9B39A5E5D2E4F3C0",
+			},
+			algo: "rx/0", seconds: 45.711, hashrate: 21876.4, hashSum: "9B39A5E5D2E4F3C0", stopped: true,
+		},
+		{
+			name: "colored",
+			lines: []string{
+				"[2024-01-01 10:02:41.432]  \x1b[1;33mbench   \x1b[0m start benchmark \x1b[1;37mhashes \x1b[0m\x1b[1;36m10M\x1b[0m \x1b[1;37malgo \x1b[0m\x1b[1;36mrx/0\x1b[0m",
+				"[2024-01-01 10:09:01.002]  \x1b[1;33mbench   \x1b[0m \x1b[1;37mbenchmark finished in \x1b[0m\x1b[1;36m379.500 seconds (26350.5 h/s)\x1b[0m\x1b[1;37m hash sum = \x1b[0m\x1b[1;32m7f2a5bd1e0c4a9d3\x1b[0m",
+			},
+			algo: "rx/0", seconds: 379.5, hashrate: 26350.5, hashSum: "7F2A5BD1E0C4A9D3", stopped: true,
+		},
+		{
+			name: "truncated result",
+			lines: []string{
+				"[2024-01-01 10:02:41.432]  bench    start benchmark hashes 1M algo rx/wow",
+				"[2024-01-01 10:03:27.146]  bench    benchmark finished in 45.711 seco",
+			},
+			algo: "rx/wow",
+		},
+		{
+			name: "mining output only",
+			lines: []string{
+				"[2024-01-01 10:02:41.432]  miner    speed 10s/60s/15m n/a n/a n/a H/s max n/a H/s",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewWrapper(io.Discard, Config{})
+			run := &benchmarkRun{done: make(chan struct{})}
+			stopped := false
+			r.streamBenchmarkLogs(strings.NewReader(strings.Join(tt.lines, "\n")), domain.XMRigStreamStdout, run, func() { stopped = true })
+			result := run.snapshot()
+			if result.Algo != tt.algo || stopped != tt.stopped {
+				t.Fatalf("algo %q, stopped %t; want %q, %t", result.Algo, stopped, tt.algo, tt.stopped)
+			}
+			if !tt.stopped {
+				if result.HashrateHS != nil || result.Seconds != nil {
+					t.Fatalf("got a result from %q", tt.lines)
+				}
+				return
+			}
+			if result.Seconds == nil || *result.Seconds != tt.seconds || result.HashrateHS == nil || *result.HashrateHS != tt.hashrate || result.HashSum != tt.hashSum {
+				t.Fatalf("result = %v s, %v H/s, %q; want %v s, %v H/s, %q", result.Seconds, result.HashrateHS, result.HashSum, tt.seconds, tt.hashrate, tt.hashSum)
+			}
+		})
+	}
+}
+
+// newBenchmarkWrapper runs benchmarks with a stand-in xmrig that never
+// produces a result.
+func newBenchmarkWrapper(t *testing.T) *Wrapper {
+	t.Helper()
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "xmrig")
+	script := "#!/bin/sh\nif [ \"$1\" = --version ]; then echo 'XMRig 6.21.0'; exit 0; fi\nexec sleep 30\n"
+	if err := os.WriteFile(binary, []byte(script), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	return NewWrapper(io.Discard, Config{
+		BinaryPath:      binary,
+		ConfigPath:      filepath.Join(dir, "run", "xmrig.json"),
+		StopGracePeriod: time.Second,
+	})
+}
+
+func queueTestBenchmark(t *testing.T, r *Wrapper) *benchmarkRun {
+	t.Helper()
+	run, err := newBenchmarkRun(domain.XMRigBenchmarkRequest{Size: domain.XMRigBenchmark1M}, r.baseMiner())
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.mu.Lock()
+	r.benchmark = run
+	r.mu.Unlock()
+	return run
+}
+
+func TestBenchmarkRefusedDuringHold(t *testing.T) {
+	r := newBenchmarkWrapper(t)
+	r.setHold(holdIdle, true)
+	run := queueTestBenchmark(t, r)
+	err := r.runBenchmark(context.Background(), run)
+	if !errors.Is(err, errBenchmarkHeld) {
+		t.Fatalf("runBenchmark() = %v, want errBenchmarkHeld", err)
+	}
+	if result := run.snapshot(); result.Status != domain.XMRigBenchmarkInvalid {
+		t.Fatalf("status = %s, want %s", result.Status, domain.XMRigBenchmarkInvalid)
+	}
+
+	r.setHold(holdIdle, false)
+	r.setThreadLimit(2)
+	run = queueTestBenchmark(t, r)
+	if err := r.runBenchmark(context.Background(), run); !errors.Is(err, errBenchmarkHeld) {
+		t.Fatalf("runBenchmark() = %v during a throttle, want errBenchmarkHeld", err)
+	}
+}
+
+func TestBenchmarkInvalidatedByHold(t *testing.T) {
+	r := newBenchmarkWrapper(t)
+	run := queueTestBenchmark(t, r)
+	finished := make(chan error, 1)
+	go func() { finished <- r.runBenchmark(context.Background(), run) }()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for r.pid() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("benchmark did not start")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	r.setHold(holdThermal, true)
+	select {
+	case err := <-finished:
+		if !errors.Is(err, errBenchmarkHeld) {
+			t.Fatalf("runBenchmark() = %v, want errBenchmarkHeld", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("benchmark kept running under a hold")
+	}
+	result := run.snapshot()
+	if result.Status != domain.XMRigBenchmarkInvalid || !strings.Contains(result.Error, "thermal hold") {
+		t.Fatalf("result = %s %q, want invalid by the thermal hold", result.Status, result.Error)
+	}
+}
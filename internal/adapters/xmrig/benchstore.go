@@ -0,0 +1,246 @@
+package xmrig
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/restartfu/grid-node/internal/domain"
+	"github.com/restartfu/grid-node/internal/observability"
+)
+
+// maxBenchmarks is how many runs are kept in memory; the file keeps all.
+const maxBenchmarks = 100
+
+// benchmarkStore keeps finished runs, oldest first, and appends each one to
+// a JSON lines file when a path is configured.
+type benchmarkStore struct {
+	mu   sync.Mutex
+	path string
+	runs []domain.XMRigBenchmark
+}
+
+type benchmarkRecord struct {
+	ID         string                `json:"id"`
+	Size       string                `json:"size"`
+	Status     string                `json:"status"`
+	Proposed   bool                  `json:"proposed"`
+	StartTime  time.Time             `json:"start_time"`
+	EndTime    *time.Time            `json:"end_time,omitempty"`
+	Algo       string                `json:"algo,omitempty"`
+	Seconds    *float64              `json:"seconds,omitempty"`
+	HashrateHS *float64              `json:"hashrate_hs,omitempty"`
+	HashSum    string                `json:"hash_sum,omitempty"`
+	Config     benchmarkConfigRecord `json:"config"`
+	Specs      *specsRecord          `json:"specs,omitempty"`
+	TempStartC *float64              `json:"temp_start_c,omitempty"`
+	TempMaxC   *float64              `json:"temp_max_c,omitempty"`
+	TempEndC   *float64              `json:"temp_end_c,omitempty"`
+	PowerW     *float64              `json:"power_w,omitempty"`
+	Error      string                `json:"error,omitempty"`
+}
+
+type benchmarkConfigRecord struct {
+	Threads      int      `json:"threads"`
+	Affinity     []int    `json:"affinity,omitempty"`
+	Priority     int      `json:"priority"`
+	HugePages    bool     `json:"huge_pages"`
+	HugePagesJIT bool     `json:"huge_pages_jit"`
+	Yield        bool     `json:"yield"`
+	RandomXMode  string   `json:"randomx_mode"`
+	OneGBPages   bool     `json:"1gb_pages"`
+	InitThreads  int      `json:"init_threads"`
+	WrMSR        bool     `json:"wrmsr"`
+	Args         []string `json:"args,omitempty"`
+}
+
+type specsRecord struct {
+	Model       string `json:"model"`
+	Cores       int    `json:"cores"`
+	Threads     int    `json:"threads"`
+	Motherboard string `json:"motherboard"`
+	CPUTemp     string `json:"cpu_temp"`
+	CPUWattage  string `json:"cpu_wattage"`
+	RAM         string `json:"ram"`
+	RAMSpeed    string `json:"ram_speed"`
+}
+
+// openBenchmarkStore loads earlier runs from path; an empty path keeps runs
+// in memory only.
+func openBenchmarkStore(path string) (*benchmarkStore, error) {
+	store := &benchmarkStore{path: path}
+	if path == "" {
+		return store, nil
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return store, err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var record benchmarkRecord
+		if json.Unmarshal(scanner.Bytes(), &record) != nil {
+			continue
+		}
+		store.runs = append(store.runs, record.benchmark())
+	}
+	store.trim()
+	return store, scanner.Err()
+}
+
+func (s *benchmarkStore) add(run domain.XMRigBenchmark) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.runs = append(s.runs, run)
+	s.trim()
+	if s.path == "" {
+		return
+	}
+	if err := s.append(run); err != nil {
+		log.Printf("xmrig benchmark store: %v", err)
+		observability.CaptureError(err, map[string]string{
+			"component": "xmrig",
+			"operation": "benchmark_store",
+		}, map[string]interface{}{
+			"path": s.path,
+		})
+	}
+}
+
+func (s *benchmarkStore) append(run domain.XMRigBenchmark) error {
+	data, err := json.Marshal(newBenchmarkRecord(run))
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func (s *benchmarkStore) trim() {
+	if len(s.runs) > maxBenchmarks {
+		s.runs = append([]domain.XMRigBenchmark(nil), s.runs[len(s.runs)-maxBenchmarks:]...)
+	}
+}
+
+// list returns the runs newest first.
+func (s *benchmarkStore) list() []domain.XMRigBenchmark {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	runs := make([]domain.XMRigBenchmark, 0, len(s.runs))
+	for i := len(s.runs) - 1; i >= 0; i-- {
+		runs = append(runs, copyBenchmark(s.runs[i]))
+	}
+	return runs
+}
+
+func newBenchmarkRecord(run domain.XMRigBenchmark) benchmarkRecord {
+	record := benchmarkRecord{
+		ID:         run.ID,
+		Size:       run.Size,
+		Status:     run.Status,
+		Proposed:   run.Proposed,
+		StartTime:  run.StartTime,
+		EndTime:    run.EndTime,
+		Algo:       run.Algo,
+		Seconds:    run.Seconds,
+		HashrateHS: run.HashrateHS,
+		HashSum:    run.HashSum,
+		Config: benchmarkConfigRecord{
+			Threads:      run.Config.Threads,
+			Affinity:     run.Config.Affinity,
+			Priority:     run.Config.Priority,
+			HugePages:    run.Config.HugePages,
+			HugePagesJIT: run.Config.HugePagesJIT,
+			Yield:        run.Config.Yield,
+			RandomXMode:  run.Config.RandomXMode,
+			OneGBPages:   run.Config.OneGBPages,
+			InitThreads:  run.Config.InitThreads,
+			WrMSR:        run.Config.WrMSR,
+			Args:         run.Config.Args,
+		},
+		TempStartC: run.TempStartC,
+		TempMaxC:   run.TempMaxC,
+		TempEndC:   run.TempEndC,
+		PowerW:     run.PowerW,
+		Error:      run.Error,
+	}
+	if run.Specs != nil {
+		record.Specs = &specsRecord{
+			Model:       run.Specs.Model,
+			Cores:       run.Specs.Cores,
+			Threads:     run.Specs.Threads,
+			Motherboard: run.Specs.Motherboard,
+			CPUTemp:     run.Specs.CPUTemp,
+			CPUWattage:  run.Specs.CPUWattage,
+			RAM:         run.Specs.RAM,
+			RAMSpeed:    run.Specs.RAMSpeed,
+		}
+	}
+	return record
+}
+
+func (r benchmarkRecord) benchmark() domain.XMRigBenchmark {
+	run := domain.XMRigBenchmark{
+		ID:         r.ID,
+		Size:       r.Size,
+		Status:     r.Status,
+		Proposed:   r.Proposed,
+		StartTime:  r.StartTime,
+		EndTime:    r.EndTime,
+		Algo:       r.Algo,
+		Seconds:    r.Seconds,
+		HashrateHS: r.HashrateHS,
+		HashSum:    r.HashSum,
+		Config: domain.XMRigBenchmarkConfig{
+			Threads:      r.Config.Threads,
+			Affinity:     r.Config.Affinity,
+			Priority:     r.Config.Priority,
+			HugePages:    r.Config.HugePages,
+			HugePagesJIT: r.Config.HugePagesJIT,
+			Yield:        r.Config.Yield,
+			RandomXMode:  r.Config.RandomXMode,
+			OneGBPages:   r.Config.OneGBPages,
+			InitThreads:  r.Config.InitThreads,
+			WrMSR:        r.Config.WrMSR,
+			Args:         r.Config.Args,
+		},
+		TempStartC: r.TempStartC,
+		TempMaxC:   r.TempMaxC,
+		TempEndC:   r.TempEndC,
+		PowerW:     r.PowerW,
+		Error:      r.Error,
+	}
+	if r.Specs != nil {
+		run.Specs = &domain.Specs{
+			Model:       r.Specs.Model,
+			Cores:       r.Specs.Cores,
+			Threads:     r.Specs.Threads,
+			Motherboard: r.Specs.Motherboard,
+			CPUTemp:     r.Specs.CPUTemp,
+			CPUWattage:  r.Specs.CPUWattage,
+			RAM:         r.Specs.RAM,
+			RAMSpeed:    r.Specs.RAMSpeed,
+		}
+	}
+	return run
+}
@@ -0,0 +1,246 @@
+package xmrig
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/restartfu/grid-node/internal/domain"
+	"github.com/restartfu/grid-node/internal/observability"
+)
+
+// binaryRefusal is why a launch was refused; operation names it in Sentry.
+type binaryRefusal struct {
+	operation string
+	err       error
+	extra     map[string]interface{}
+}
+
+// prepareBinary resolves the xmrig executable and returns the path to run.
+// Without AllowedSHA256 that is the looked-up path itself. With it, the
+// binary is copied into a private directory and hashed as it is copied; the
+// copy is what gets probed and run, so the file cannot be swapped between the
+// check and the launch, and a binary not in AllowedSHA256 is never executed,
+// not even for --version. Hashing and --version are repeated only when the
+// file's size, mtime or inode change.
+func (r *Wrapper) prepareBinary(ctx context.Context) (string, *binaryRefusal) {
+	path, err := exec.LookPath(r.config.BinaryPath)
+	if err != nil {
+		return "", &binaryRefusal{operation: "lookup", err: err, extra: map[string]interface{}{
+			"binary": r.config.BinaryPath,
+		}}
+	}
+	file, err := statBinary(path)
+	if err != nil {
+		return "", &binaryRefusal{operation: "lookup", err: err, extra: map[string]interface{}{
+			"binary": r.config.BinaryPath,
+		}}
+	}
+	pinned := len(r.config.AllowedSHA256) > 0
+	binary, known := r.state.binaryFor(file)
+	run, sum := path, ""
+	if pinned {
+		staged, hashed, refused := r.stagePinned(file, binary, known)
+		if refused != nil {
+			return "", refused
+		}
+		run, sum = staged, hashed
+	}
+
+	if !known {
+		binary = r.probeVersion(ctx, run)
+		binary.Path = path
+		binary.SHA256 = sum
+		binary.Pinned = pinned
+		binary.MinVersion = r.config.MinVersion
+		r.state.setBinary(binary, file)
+	}
+	if r.config.MinVersion == "" {
+		return run, nil
+	}
+	if binary.Version == "" {
+		err := fmt.Errorf("xmrig at %s has an unknown version, %s or newer is required", path, r.config.MinVersion)
+		return "", &binaryRefusal{operation: "version_check", err: err, extra: map[string]interface{}{
+			"path": path,
+		}}
+	}
+	if err := checkMinVersion(binary.Version, r.config.MinVersion); err != nil {
+		return "", &binaryRefusal{operation: "version_check", err: fmt.Errorf("xmrig at %s: %w", path, err), extra: map[string]interface{}{
+			"path":        path,
+			"version":     binary.Version,
+			"min_version": r.config.MinVersion,
+		}}
+	}
+	return run, nil
+}
+
+// detectBinary probes xmrig once at startup so the status shows its version
+// before the first launch; later launches probe again only when the file
+// changed. A refusal is only logged here and reported when a launch is
+// attempted.
+func (r *Wrapper) detectBinary(ctx context.Context) {
+	if _, refused := r.prepareBinary(ctx); refused != nil {
+		log.Printf("xmrig binary: %v", refused.err)
+	}
+}
+
+// stagePinned returns the private copy of file and its hash, refusing a hash
+// not in AllowedSHA256. The copy and hash from an earlier launch are reused
+// while file is unchanged.
+func (r *Wrapper) stagePinned(file binaryFile, cached domain.XMRigBinary, known bool) (string, string, *binaryRefusal) {
+	dir := binaryDir(r.config.ConfigPath)
+	if err := privateDir(dir); err != nil {
+		return "", "", &binaryRefusal{operation: "binary_stage", err: err, extra: map[string]interface{}{
+			"dir": dir,
+		}}
+	}
+	staged := filepath.Join(dir, "xmrig")
+	sum := ""
+	if known {
+		sum = cached.SHA256
+	}
+	allowed := sum != "" && contains(r.config.AllowedSHA256, sum)
+	if sum == "" || (allowed && !fileExists(staged)) {
+		var err error
+		staged, sum, err = stageBinary(file.path, dir)
+		if err != nil {
+			return "", "", &binaryRefusal{operation: "binary_hash", err: fmt.Errorf("copy %s: %w", file.path, err), extra: map[string]interface{}{
+				"path": file.path,
+			}}
+		}
+	}
+	if !contains(r.config.AllowedSHA256, sum) {
+		os.Remove(staged)
+		r.state.setBinary(domain.XMRigBinary{
+			Path:       file.path,
+			SHA256:     sum,
+			MinVersion: r.config.MinVersion,
+			DetectedAt: time.Now().UTC(),
+		}, file)
+		err := fmt.Errorf("xmrig at %s has SHA-256 %s, which is not an allowed hash; refusing to start it", file.path, sum)
+		return "", "", &binaryRefusal{operation: "binary_hash", err: err, extra: map[string]interface{}{
+			"path":    file.path,
+			"sha256":  sum,
+			"allowed": r.config.AllowedSHA256,
+		}}
+	}
+	return staged, sum, nil
+}
+
+// probeVersion runs xmrig --version. A failure is reported but only blocks
+// the launch when a minimum version is required.
+func (r *Wrapper) probeVersion(ctx context.Context, path string) domain.XMRigBinary {
+	probeCtx, cancel := context.WithTimeout(ctx, versionTimeout)
+	defer cancel()
+	binary, err := probeBinary(probeCtx, path)
+	if err != nil {
+		log.Printf("xmrig version: %v", err)
+		observability.CaptureError(err, map[string]string{
+			"component": "xmrig",
+			"operation": "version_probe",
+		}, map[string]interface{}{
+			"path": path,
+		})
+	}
+	return binary
+}
+
+// binaryDir holds the verified xmrig copy, next to the rendered config.
+func binaryDir(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), "bin")
+}
+
+// stageBinary copies path to dir/xmrig and returns the copy and the SHA-256
+// of exactly the bytes written to it.
+func stageBinary(path, dir string) (string, string, error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return "", "", err
+	}
+	defer src.Close()
+	dst, err := os.CreateTemp(dir, ".xmrig-*")
+	if err != nil {
+		return "", "", err
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(dst, hash), src); err != nil {
+		dst.Close()
+		os.Remove(dst.Name())
+		return "", "", err
+	}
+	if err := dst.Chmod(0o700); err != nil {
+		dst.Close()
+		os.Remove(dst.Name())
+		return "", "", err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(dst.Name())
+		return "", "", err
+	}
+	staged := filepath.Join(dir, "xmrig")
+	if err := os.Rename(dst.Name(), staged); err != nil {
+		os.Remove(dst.Name())
+		return "", "", err
+	}
+	return staged, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// binaryFile identifies an executable on disk. Any change means it has to be
+// hashed and probed again.
+type binaryFile struct {
+	path  string
+	size  int64
+	mtime time.Time
+	dev   uint64
+	inode uint64
+}
+
+func statBinary(path string) (binaryFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return binaryFile{}, err
+	}
+	file := binaryFile{path: path, size: info.Size(), mtime: info.ModTime()}
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		file.dev = uint64(stat.Dev)
+		file.inode = uint64(stat.Ino)
+	}
+	return file, nil
+}
+
+func (s *state) setBinary(binary domain.XMRigBinary, file binaryFile) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.binary = &binary
+	s.binaryFile = file
+}
+
+// binaryFor returns the recorded binary if it was taken from file as it is
+// now.
+func (s *state) binaryFor(file binaryFile) (domain.XMRigBinary, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.binary == nil || s.binaryFile != file {
+		return domain.XMRigBinary{}, false
+	}
+	return *s.binary, true
+}
+
+func (s *state) binarySnapshot() *domain.XMRigBinary {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.binary == nil {
+		return nil
+	}
+	binary := *s.binary
+	binary.Features = append([]string(nil), binary.Features...)
+	return &binary
+}
@@ -0,0 +1,89 @@
+package xmrig
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeFakeXMRig(t *testing.T, path, version string) string {
+	t.Helper()
+	script := "#!/bin/sh\necho 'XMRig " + version + "'\n"
+	if err := os.WriteFile(path, []byte(script), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256([]byte(script))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestPrepareBinaryRunsUnpinnedBinaryInPlace(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "xmrig")
+	writeFakeXMRig(t, path, "6.21.0")
+	r := NewWrapper(io.Discard, Config{BinaryPath: path, ConfigPath: filepath.Join(dir, "run", "xmrig.json")})
+
+	run, refused := r.prepareBinary(context.Background())
+	if refused != nil {
+		t.Fatal(refused.err)
+	}
+	if run != path {
+		t.Fatalf("prepareBinary() = %s, want %s", run, path)
+	}
+	if fileExists(binaryDir(r.config.ConfigPath)) {
+		t.Error("an unpinned binary was staged")
+	}
+	binary := r.Status().Binary
+	if binary.Version != "6.21.0" || binary.SHA256 != "" || binary.Pinned {
+		t.Fatalf("binary = %+v, want version 6.21.0, no hash and not pinned", binary)
+	}
+}
+
+func TestPrepareBinaryRehashesOnlyChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "xmrig")
+	sum := writeFakeXMRig(t, path, "6.21.0")
+	r := NewWrapper(io.Discard, Config{
+		BinaryPath:    path,
+		ConfigPath:    filepath.Join(dir, "run", "xmrig.json"),
+		AllowedSHA256: []string{sum},
+	})
+	run, refused := r.prepareBinary(context.Background())
+	if refused != nil {
+		t.Fatal(refused.err)
+	}
+	if run != filepath.Join(binaryDir(r.config.ConfigPath), "xmrig") {
+		t.Fatalf("prepareBinary() = %s, want the staged copy", run)
+	}
+
+	// Same size, mtime and inode: the staged copy and its hash are reused.
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFakeXMRig(t, path, "6.22.0")
+	if err := os.Chtimes(path, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+	if _, refused := r.prepareBinary(context.Background()); refused != nil {
+		t.Fatalf("unchanged file refused: %v", refused.err)
+	}
+	if version := r.Status().Binary.Version; version != "6.21.0" {
+		t.Fatalf("version = %s, want the cached 6.21.0", version)
+	}
+
+	later := info.ModTime().Add(time.Second)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if _, refused := r.prepareBinary(context.Background()); refused == nil || refused.operation != "binary_hash" {
+		t.Fatalf("prepareBinary() = %v after the file changed, want a binary_hash refusal", refused)
+	}
+	if fileExists(run) {
+		t.Error("the rejected copy was left staged")
+	}
+}
@@ -1,44 +1,359 @@
 package xmrig
 
-import "time"
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
 
-const maxLogs = 250
+	"github.com/restartfu/grid-node/internal/domain"
+	"github.com/restartfu/grid-node/internal/schedule"
+	"github.com/restartfu/grid-node/internal/stratum"
+)
+
+const defaultLogBufferSize = 250
+
+// maxStderrTail is how many stderr lines are folded into LastError on a crash.
+const maxStderrTail = 5
 
 const defaultRestartDelay = 5 * time.Second
 
+const defaultMaxRestartDelay = 5 * time.Minute
+
+const defaultStableUptime = 2 * time.Minute
+
+const defaultCrashLoopExits = 5
+
+const defaultCrashLoopWindow = 10 * time.Minute
+
+const defaultStopGracePeriod = 10 * time.Second
+
+const defaultAPIPollInterval = 5 * time.Second
+
+const defaultRandomXMode = "auto"
+
+const defaultBinaryPath = "xmrig"
+
+// defaultConfigPath is per user, since the rendered config carries the API
+// token: the user cache directory, or a directory in the temp directory named
+// after the uid when there is no home. The systemd unit points it at its
+// StateDirectory instead.
+func defaultConfigPath() string {
+	if dir, err := os.UserCacheDir(); err == nil {
+		return filepath.Join(dir, "grid-node", "xmrig.json")
+	}
+	return filepath.Join(os.TempDir(), "grid-node-"+strconv.Itoa(os.Geteuid()), "xmrig.json")
+}
+
+// defaultHashrateHistorySize covers 24h of samples at xmrig's 5s print interval.
+const defaultHashrateHistorySize = 17280
+
+// defaultThermalHysteresis is how far below the trip point mining resumes
+// when no resume threshold is configured.
+const defaultThermalHysteresis = 10.0
+
+const defaultThermalMinHold = 5 * time.Minute
+
+const defaultThermalInterval = 10 * time.Second
+
+// maxThermalEvents is how many thermal interventions are kept in the status.
+const maxThermalEvents = 20
+
+const defaultIdleThreshold = 25.0
+
+const defaultIdleBusyFor = 30 * time.Second
+
+const defaultIdleIdleFor = 2 * time.Minute
+
+const defaultIdleInterval = 5 * time.Second
+
+const defaultFailoverThreshold = 3
+
+const defaultRetryPrimary = 15 * time.Minute
+
+// maxFailoverEvents is how many pool switches are kept in the status.
+const maxFailoverEvents = 20
+
+const defaultProbeInterval = time.Minute
+
+const defaultProbeTimeout = 5 * time.Second
+
+const defaultResourceInterval = 10 * time.Second
+
+// defaultResourceHistorySize covers 1h of samples at the default interval.
+const defaultResourceHistorySize = 360
+
+const defaultBenchmarkTimeout = time.Hour
+
+const defaultTuneTimeout = 6 * time.Hour
+
+const defaultLogFileMaxSize = 50 << 20
+
+const defaultLogFileMaxAge = 24 * time.Hour
+
+const defaultLogFileMaxBackups = 7
+
 type Config struct {
-	Args         []string
-	RestartDelay time.Duration
+	// BinaryPath is the xmrig executable, looked up in PATH when it has no
+	// slash.
+	BinaryPath string
+	// MinVersion, when set, refuses to start older xmrig binaries.
+	MinVersion string
+	// AllowedSHA256, when set, lists the lowercase hex SHA-256 hashes the
+	// binary must match before every launch. Without it the binary is not
+	// hashed at all.
+	AllowedSHA256 []string
+	// Miner is rendered to ConfigPath before every launch. The verified
+	// xmrig copy that is actually run lives in bin/ next to it.
+	Miner      MinerConfig
+	ConfigPath string
+	// ExtraArgs are xmrig flags appended after --config; xmrig lets them
+	// override the rendered file.
+	ExtraArgs []string
+	// RestartDelay is the first backoff step after an unexpected exit; it
+	// doubles on every further exit up to MaxRestartDelay.
+	RestartDelay    time.Duration
+	MaxRestartDelay time.Duration
+	// StableUptime is how long xmrig must stay up for the backoff to reset.
+	StableUptime time.Duration
+	// CrashLoopExits exits within CrashLoopWindow mark xmrig as crash looping.
+	CrashLoopExits  int
+	CrashLoopWindow time.Duration
+	// StopGracePeriod is how long xmrig gets to exit after SIGTERM before its
+	// process group is killed.
+	StopGracePeriod time.Duration
+	// LogBufferSize is how many log lines are kept in memory.
+	LogBufferSize int
+	// HashrateHistorySize is how many hashrate samples are kept in memory.
+	HashrateHistorySize int
+	// APIPort is the localhost port for xmrig's HTTP API. Zero picks a free
+	// port on every launch and a negative value disables the API.
+	APIPort         int
+	APIPollInterval time.Duration
+	// ResourceInterval is how often the process is sampled from /proc and
+	// ResourceHistorySize how many samples are kept.
+	ResourceInterval    time.Duration
+	ResourceHistorySize int
+	// LogFile configures the persistent on-disk log.
+	LogFile LogFileConfig
+	Thermal ThermalConfig
+	Idle    IdleConfig
+	// Failover applies when Miner lists more than one pool.
+	Failover FailoverConfig
+	// Proxy, when set, sits between xmrig and the active pool.
+	Proxy *stratum.Proxy
+	Probe ProbeConfig
+	// BenchmarkFile, when set, keeps benchmark results across restarts.
+	BenchmarkFile string
+	// BenchmarkTimeout stops a benchmark that produced no result in time.
+	BenchmarkTimeout time.Duration
+	// TuneTimeout ends an auto-tune sweep that has not finished in time.
+	TuneTimeout time.Duration
+	// TuneFile, when set, keeps the auto-tune profile across restarts.
+	TuneFile string
+	// Schedule, when set, starts, stops or re-profiles xmrig at window
+	// boundaries.
+	Schedule *schedule.Schedule
 }
 
-var defaultArgs = []string{
-	"--url=tokyo:3333",
-	"--user=%H",
-	"--pass=%H",
-	"--algo=rx/monero",
-	"--cpu-priority=5",
-	"--randomx-1gb-pages",
-	"--huge-pages",
-	"--no-color",
-	"--print-time=5",
+type ThermalConfig struct {
+	// TripC enables the guard when positive; at or above it mining is paused
+	// or throttled until the temperature drops to ResumeC and MinHold passed.
+	TripC    float64
+	ResumeC  float64
+	MinHold  time.Duration
+	Interval time.Duration
+	// Action is domain.XMRigThermalPause or domain.XMRigThermalThrottle.
+	Action string
+	// ThrottleThreads is the thread count xmrig is restarted with when throttled.
+	ThrottleThreads int
 }
 
-func copyDefaultArgs() []string {
-	args := make([]string, len(defaultArgs))
-	copy(args, defaultArgs)
-	return args
+// IdleConfig enables idle-only mining: xmrig is paused once load from other
+// processes stays at or above Threshold percent of all CPUs for BusyFor and
+// continued after it stayed below for IdleFor.
+type IdleConfig struct {
+	Enabled   bool
+	Threshold float64
+	BusyFor   time.Duration
+	IdleFor   time.Duration
+	Interval  time.Duration
+}
+
+// FailoverConfig moves xmrig to the next pool in order after Threshold
+// consecutive connection or login failures, and back to the first pool after
+// RetryPrimary on a fallback.
+type FailoverConfig struct {
+	Threshold    int
+	RetryPrimary time.Duration
+}
+
+// ProbeConfig controls the pool connectivity probe. A negative Interval
+// disables it.
+type ProbeConfig struct {
+	Interval time.Duration
+	Timeout  time.Duration
+}
+
+func (c ProbeConfig) enabled() bool {
+	return c.Interval >= 0
+}
+
+// failoverEnabled reports whether miner has a fallback pool to switch to.
+func failoverEnabled(miner MinerConfig) bool {
+	return len(miner.Pools) > 1
+}
+
+func (c ThermalConfig) enabled() bool {
+	return c.TripC > 0
 }
 
 func normalizeConfig(cfg Config) Config {
 	if cfg.RestartDelay <= 0 {
 		cfg.RestartDelay = defaultRestartDelay
 	}
-	if len(cfg.Args) == 0 {
-		cfg.Args = copyDefaultArgs()
-	} else {
-		args := make([]string, len(cfg.Args))
-		copy(args, cfg.Args)
-		cfg.Args = args
+	if cfg.MaxRestartDelay <= 0 {
+		cfg.MaxRestartDelay = defaultMaxRestartDelay
+	}
+	if cfg.MaxRestartDelay < cfg.RestartDelay {
+		cfg.MaxRestartDelay = cfg.RestartDelay
 	}
+	if cfg.StableUptime <= 0 {
+		cfg.StableUptime = defaultStableUptime
+	}
+	if cfg.CrashLoopExits <= 0 {
+		cfg.CrashLoopExits = defaultCrashLoopExits
+	}
+	if cfg.CrashLoopWindow <= 0 {
+		cfg.CrashLoopWindow = defaultCrashLoopWindow
+	}
+	if cfg.LogBufferSize <= 0 {
+		cfg.LogBufferSize = defaultLogBufferSize
+	}
+	if cfg.HashrateHistorySize <= 0 {
+		cfg.HashrateHistorySize = defaultHashrateHistorySize
+	}
+	if cfg.StopGracePeriod <= 0 {
+		cfg.StopGracePeriod = defaultStopGracePeriod
+	}
+	if cfg.APIPollInterval <= 0 {
+		cfg.APIPollInterval = defaultAPIPollInterval
+	}
+	if cfg.ResourceInterval <= 0 {
+		cfg.ResourceInterval = defaultResourceInterval
+	}
+	if cfg.ResourceHistorySize <= 0 {
+		cfg.ResourceHistorySize = defaultResourceHistorySize
+	}
+	if cfg.BenchmarkTimeout <= 0 {
+		cfg.BenchmarkTimeout = defaultBenchmarkTimeout
+	}
+	if cfg.TuneTimeout <= 0 {
+		cfg.TuneTimeout = defaultTuneTimeout
+	}
+	if cfg.LogFile.MaxSize <= 0 {
+		cfg.LogFile.MaxSize = defaultLogFileMaxSize
+	}
+	if cfg.LogFile.MaxAge <= 0 {
+		cfg.LogFile.MaxAge = defaultLogFileMaxAge
+	}
+	if cfg.LogFile.MaxBackups <= 0 {
+		cfg.LogFile.MaxBackups = defaultLogFileMaxBackups
+	}
+	if cfg.Thermal.enabled() {
+		cfg.Thermal = normalizeThermalConfig(cfg.Thermal)
+	}
+	if cfg.Idle.Enabled {
+		cfg.Idle = normalizeIdleConfig(cfg.Idle)
+	}
+	if len(cfg.Miner.Pools) == 0 && cfg.Miner.RandomX.Mode == "" {
+		cfg.Miner = DefaultMinerConfig()
+	}
+	if cfg.Failover.Threshold <= 0 {
+		cfg.Failover.Threshold = defaultFailoverThreshold
+	}
+	// A non-positive RetryPrimary would retry the primary on every check.
+	if cfg.Failover.RetryPrimary <= 0 {
+		cfg.Failover.RetryPrimary = defaultRetryPrimary
+	}
+	if cfg.Probe.Interval == 0 {
+		cfg.Probe.Interval = defaultProbeInterval
+	}
+	if cfg.Probe.Timeout <= 0 {
+		cfg.Probe.Timeout = defaultProbeTimeout
+	}
+	if cfg.BinaryPath == "" {
+		cfg.BinaryPath = defaultBinaryPath
+	}
+	if cfg.ConfigPath == "" {
+		cfg.ConfigPath = defaultConfigPath()
+	}
+	cfg.ExtraArgs = append([]string(nil), cfg.ExtraArgs...)
+	cfg.AllowedSHA256 = append([]string(nil), cfg.AllowedSHA256...)
 	return cfg
 }
+
+func normalizeThermalConfig(cfg ThermalConfig) ThermalConfig {
+	if cfg.ResumeC <= 0 || cfg.ResumeC >= cfg.TripC {
+		cfg.ResumeC = cfg.TripC - defaultThermalHysteresis
+	}
+	if cfg.MinHold <= 0 {
+		cfg.MinHold = defaultThermalMinHold
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultThermalInterval
+	}
+	if cfg.Action == "" {
+		cfg.Action = domain.XMRigThermalPause
+	}
+	if cfg.ThrottleThreads <= 0 {
+		cfg.ThrottleThreads = runtime.NumCPU() / 2
+		if cfg.ThrottleThreads < 1 {
+			cfg.ThrottleThreads = 1
+		}
+	}
+	return cfg
+}
+
+func normalizeIdleConfig(cfg IdleConfig) IdleConfig {
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = defaultIdleThreshold
+	}
+	if cfg.BusyFor <= 0 {
+		cfg.BusyFor = defaultIdleBusyFor
+	}
+	if cfg.IdleFor <= 0 {
+		cfg.IdleFor = defaultIdleIdleFor
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultIdleInterval
+	}
+	return cfg
+}
+
+// argValue returns the value of the last "--name=value" or "--name value" flag.
+func argValue(args []string, name string) (string, bool) {
+	value, found := "", false
+	for i, arg := range args {
+		if arg == name && i+1 < len(args) {
+			value, found = args[i+1], true
+			continue
+		}
+		if strings.HasPrefix(arg, name+"=") {
+			value, found = strings.TrimPrefix(arg, name+"="), true
+		}
+	}
+	return value, found
+}
+
+func randomXMode(miner MinerConfig, args []string) string {
+	if value, ok := argValue(args, "--randomx-mode"); ok && value != "" {
+		return value
+	}
+	if miner.RandomX.Mode != "" {
+		return miner.RandomX.Mode
+	}
+	return defaultRandomXMode
+}
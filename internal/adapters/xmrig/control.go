@@ -0,0 +1,130 @@
+package xmrig
+
+import (
+	"errors"
+	"syscall"
+
+	"github.com/restartfu/grid-node/internal/domain"
+)
+
+// StartMining continues a paused xmrig unless a thermal or idle hold keeps it
+// paused; it continues once the hold is released.
+func (r *Wrapper) StartMining() error {
+	r.state.setDesired(domain.XMRigDesiredRunning)
+	r.applyHolds()
+	r.notify()
+	return nil
+}
+
+func (r *Wrapper) StopMining() error {
+	r.state.setDesired(domain.XMRigDesiredStopped)
+	r.stopProcess(domain.XMRigStopOperator)
+	r.notify()
+	return nil
+}
+
+func (r *Wrapper) RestartMining() error {
+	r.state.setDesired(domain.XMRigDesiredRunning)
+	r.stopProcess(domain.XMRigStopRestart)
+	r.notify()
+	return nil
+}
+
+// PauseMining suspends the running xmrig process without tearing it down.
+func (r *Wrapper) PauseMining() error {
+	if err := r.signalProcess(syscall.SIGSTOP); err != nil {
+		return err
+	}
+	r.state.setPaused(true)
+	r.state.setDesired(domain.XMRigDesiredPaused)
+	return nil
+}
+
+// Holds are reasons other than the operator for keeping xmrig paused.
+const (
+	holdThermal = "thermal"
+	holdIdle    = "idle"
+)
+
+// setHold sets or clears a hold and pauses or continues xmrig to match.
+func (r *Wrapper) setHold(reason string, held bool) {
+	r.mu.Lock()
+	if held {
+		r.holds[reason] = struct{}{}
+	} else {
+		delete(r.holds, reason)
+	}
+	r.mu.Unlock()
+	if held {
+		r.interruptBenchmark(reason + " hold")
+	}
+	r.applyHolds()
+}
+
+// applyHolds pauses xmrig while any hold is set and continues it once all
+// are released, unless the operator paused it.
+func (r *Wrapper) applyHolds() {
+	r.mu.Lock()
+	held := len(r.holds) > 0
+	r.mu.Unlock()
+	paused := r.state.isPaused()
+	switch {
+	case held && !paused:
+		if err := r.signalProcess(syscall.SIGSTOP); err == nil {
+			r.state.setPaused(true)
+		}
+	case !held && paused && r.state.desiredState() == domain.XMRigDesiredRunning:
+		if err := r.signalProcess(syscall.SIGCONT); err == nil {
+			r.state.setPaused(false)
+		}
+	}
+}
+
+func (r *Wrapper) setProcess(p *process) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.process = p
+}
+
+// takeProcess clears the current process and returns it.
+func (r *Wrapper) takeProcess() *process {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p := r.process
+	r.process = nil
+	return p
+}
+
+// stopProcess starts a graceful stop of the current process and records why.
+func (r *Wrapper) stopProcess(reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.process != nil {
+		r.process.reason = reason
+		r.process.cancel()
+	}
+}
+
+// signalProcess signals xmrig's whole process group, as terminateOnCancel
+// does, so anything xmrig started is paused and continued with it.
+func (r *Wrapper) signalProcess(sig syscall.Signal) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.process == nil || r.process.cmd.Process == nil {
+		return domain.ErrXMRigNotRunning
+	}
+	if err := syscall.Kill(-r.process.cmd.Process.Pid, sig); err != nil {
+		if errors.Is(err, syscall.ESRCH) {
+			return domain.ErrXMRigNotRunning
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *Wrapper) notify() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
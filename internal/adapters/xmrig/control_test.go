@@ -0,0 +1,68 @@
+package xmrig
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// processState returns the state letter from /proc/<pid>/stat.
+func processState(t *testing.T, pid int) string {
+	t.Helper()
+	data, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	if err != nil {
+		t.Skipf("no /proc: %v", err)
+	}
+	fields := strings.Fields(string(data[strings.LastIndexByte(string(data), ')')+1:]))
+	return fields[0]
+}
+
+func waitForState(t *testing.T, pid int, want string) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for processState(t, pid) != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("process %d state = %s, want %s", pid, processState(t, pid), want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSignalProcessReachesTheWholeGroup(t *testing.T) {
+	cmd := newProcessGroup("/bin/sh", []string{"-c", "sleep 30 & echo $!; wait"})
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		killProcessGroup(cmd)
+		_ = cmd.Wait()
+	}()
+	line, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	child, err := strconv.Atoi(strings.TrimSpace(line))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewWrapper(io.Discard, Config{})
+	r.setProcess(&process{cmd: cmd})
+	if err := r.signalProcess(syscall.SIGSTOP); err != nil {
+		t.Fatal(err)
+	}
+	waitForState(t, child, "T")
+	if err := r.signalProcess(syscall.SIGCONT); err != nil {
+		t.Fatal(err)
+	}
+	waitForState(t, child, "S")
+}
@@ -0,0 +1,88 @@
+package xmrig
+
+import (
+	"sync"
+
+	"github.com/restartfu/grid-node/internal/domain"
+)
+
+// eventSubscriberBuffer is how many events a subscriber may lag behind
+// before it is dropped.
+const eventSubscriberBuffer = 256
+
+// eventBus fans typed events out to subscribers. It has its own lock so
+// events can be published while the state lock is held.
+type eventBus struct {
+	mu          sync.Mutex
+	subscribers map[*eventSubscriber]struct{}
+}
+
+type eventSubscriber struct {
+	ch    chan domain.XMRigEvent
+	types map[string]struct{}
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{subscribers: make(map[*eventSubscriber]struct{})}
+}
+
+// SubscribeEvents returns a channel receiving every event of the given types,
+// or of all types when none are given. The channel is closed when the
+// subscriber falls behind; the returned func releases the subscription.
+func (r *Wrapper) SubscribeEvents(types ...string) (<-chan domain.XMRigEvent, func()) {
+	sub := r.state.events.subscribe(types)
+	return sub.ch, func() {
+		r.state.events.unsubscribe(sub)
+	}
+}
+
+// subscribeLogs returns up to backlog recent lines and a subscription to the
+// lines after them. Lines are published under s.mu, so none is missed or
+// repeated between the two.
+func (s *state) subscribeLogs(backlog int) ([]domain.XMRigLogEntry, *eventSubscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.logs.last(backlog), s.events.subscribe([]string{domain.XMRigEventLog})
+}
+
+func (b *eventBus) subscribe(types []string) *eventSubscriber {
+	sub := &eventSubscriber{ch: make(chan domain.XMRigEvent, eventSubscriberBuffer)}
+	if len(types) > 0 {
+		sub.types = make(map[string]struct{}, len(types))
+		for _, eventType := range types {
+			sub.types[eventType] = struct{}{}
+		}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[sub] = struct{}{}
+	return sub
+}
+
+func (b *eventBus) unsubscribe(sub *eventSubscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subscribers[sub]; ok {
+		delete(b.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+// publish never blocks; slow subscribers are disconnected instead.
+func (b *eventBus) publish(event domain.XMRigEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subscribers {
+		if sub.types != nil {
+			if _, ok := sub.types[event.EventType()]; !ok {
+				continue
+			}
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			delete(b.subscribers, sub)
+			close(sub.ch)
+		}
+	}
+}
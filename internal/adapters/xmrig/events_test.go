@@ -0,0 +1,119 @@
+package xmrig
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/restartfu/grid-node/internal/domain"
+)
+
+// drain returns the events already buffered on ch and whether it is closed.
+func drain(ch <-chan domain.XMRigEvent) ([]domain.XMRigEvent, bool) {
+	var events []domain.XMRigEvent
+	for {
+		select {
+		case event, ok := <-ch:
+			if !ok {
+				return events, true
+			}
+			events = append(events, event)
+		default:
+			return events, false
+		}
+	}
+}
+
+func TestEventBusFiltersByType(t *testing.T) {
+	bus := newEventBus()
+	all := bus.subscribe(nil)
+	started := bus.subscribe([]string{domain.XMRigEventStarted})
+	bus.publish(domain.XMRigStartedEvent{PID: 1})
+	bus.publish(domain.XMRigJobEvent{Pool: "pool.example:3333"})
+
+	if events, closed := drain(all.ch); closed || len(events) != 2 {
+		t.Fatalf("unfiltered subscriber got %v (closed %t), want both events", events, closed)
+	}
+	events, closed := drain(started.ch)
+	if closed || len(events) != 1 || events[0].EventType() != domain.XMRigEventStarted {
+		t.Fatalf("started subscriber got %v (closed %t), want one started event", events, closed)
+	}
+}
+
+func TestEventBusDropsSlowSubscriber(t *testing.T) {
+	bus := newEventBus()
+	slow := bus.subscribe(nil)
+	fast := bus.subscribe(nil)
+	for i := 0; i < eventSubscriberBuffer; i++ {
+		bus.publish(domain.XMRigStartedEvent{PID: i})
+	}
+	// fast keeps up; slow does not read at all.
+	drain(fast.ch)
+	bus.publish(domain.XMRigStartedEvent{PID: -1})
+
+	events, closed := drain(slow.ch)
+	if !closed || len(events) != eventSubscriberBuffer {
+		t.Fatalf("slow subscriber got %d events (closed %t), want %d then closed", len(events), closed, eventSubscriberBuffer)
+	}
+	if events, closed := drain(fast.ch); closed || len(events) != 1 {
+		t.Fatalf("fast subscriber got %d events (closed %t), want 1", len(events), closed)
+	}
+	// Unsubscribing a dropped subscriber must not close its channel twice.
+	bus.unsubscribe(slow)
+}
+
+func TestEventBusUnsubscribe(t *testing.T) {
+	bus := newEventBus()
+	sub := bus.subscribe(nil)
+	bus.unsubscribe(sub)
+	bus.unsubscribe(sub)
+	bus.publish(domain.XMRigStartedEvent{PID: 1})
+	if events, closed := drain(sub.ch); !closed || len(events) != 0 {
+		t.Fatalf("after unsubscribe got %v (closed %t), want a closed channel", events, closed)
+	}
+}
+
+func TestSubscribeLogsContinuesTheBacklog(t *testing.T) {
+	r := NewWrapper(io.Discard, Config{})
+	r.state.recordStart(time.Now().UTC(), 1)
+	r.state.recordLine("first", domain.XMRigStreamStdout, time.Now().UTC(), lineInfo{}, 1)
+	logs, updates, cancel := r.SubscribeLogs(10)
+	defer cancel()
+	r.state.recordLine("second", domain.XMRigStreamStdout, time.Now().UTC(), lineInfo{}, 1)
+
+	if len(logs) != 1 || logs[0].Line != "first" {
+		t.Fatalf("backlog = %v, want the first line", logs)
+	}
+	events, _ := drain(updates)
+	if len(events) != 1 {
+		t.Fatalf("got %v, want one log event", events)
+	}
+	if line, ok := events[0].(domain.XMRigLogEvent); !ok || line.Entry.Line != "second" || line.Entry.Seq != logs[0].Seq+1 {
+		t.Fatalf("got %#v, want the second line", events[0])
+	}
+}
+
+func TestPoolChangeWithoutDisconnectPublishesEvent(t *testing.T) {
+	r := NewWrapper(io.Discard, Config{})
+	generation := r.state.recordStart(time.Now().UTC(), 1)
+	updates, cancel := r.SubscribeEvents(domain.XMRigEventPoolConnected, domain.XMRigEventPoolDisconnected)
+	defer cancel()
+	for _, line := range []string{
+		"[2024-01-01 00:00:00.000]  net      use pool pool-a.example:3333  203.0.113.1",
+		"[2024-01-01 00:00:01.000]  net      new job from pool-a.example:3333 diff 100000 algo rx/0 height 3000000",
+		"[2024-01-01 00:01:00.000]  net      new job from pool-b.example:3333 diff 100000 algo rx/0 height 3000001",
+		"[2024-01-01 00:01:01.000]  net      new job from pool-b.example:3333 diff 120000 algo rx/0 height 3000002",
+	} {
+		r.state.recordLine(line, domain.XMRigStreamStdout, time.Now().UTC(), parseLine(line), generation)
+	}
+	events, _ := drain(updates)
+	if len(events) != 2 {
+		t.Fatalf("got %d pool events, want 2: %v", len(events), events)
+	}
+	for i, want := range []string{"pool-a.example:3333", "pool-b.example:3333"} {
+		event, ok := events[i].(domain.XMRigPoolConnectionEvent)
+		if !ok || !event.Connected || event.Pool != want {
+			t.Errorf("event %d = %#v, want a connect to %s", i, events[i], want)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package xmrig
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/restartfu/grid-node/internal/domain"
+	"github.com/restartfu/grid-node/internal/observability"
+)
+
+const (
+	failoverReasonFailures = "failures"
+	failoverReasonPrimary  = "retry_primary"
+)
+
+// failoverState tracks the active pool and its consecutive failures. Log
+// errors and the API's failure counter are counted separately and the
+// larger count wins, as both usually report the same failure.
+type failoverState struct {
+	// generation identifies the process failures are counted for. It moves
+	// on at every launch and every switch, so events from a process that is
+	// being replaced are ignored.
+	generation  uint64
+	active      int
+	activeSince time.Time
+	logFailures int
+	apiFailures int64
+	// apiBase is the API failure counter at the last sign of a healthy pool.
+	apiBase   int64
+	lastError string
+	switches  int
+	events    []domain.XMRigPoolEvent
+}
+
+func (f failoverState) failures() int {
+	failures := f.logFailures
+	if api := int(f.apiFailures - f.apiBase); api > failures {
+		failures = api
+	}
+	return failures
+}
+
+// retryPrimary moves back to the first pool once a fallback has been active
+// for RetryPrimary. If the primary is still down, failover kicks in again.
+func (r *Wrapper) retryPrimary(ctx context.Context) {
+	ticker := time.NewTicker(failoverCheckInterval(r.config.Failover.RetryPrimary))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		active, since := r.state.activePool()
+		if active != 0 && time.Since(since) >= r.config.Failover.RetryPrimary {
+			r.switchPool(0, failoverReasonPrimary)
+		}
+	}
+}
+
+// failoverCheckInterval checks ten times per RetryPrimary, between once a
+// second and every 30s; time.NewTicker panics on a zero interval.
+func failoverCheckInterval(retry time.Duration) time.Duration {
+	interval := retry / 10
+	if interval < time.Second {
+		return time.Second
+	}
+	if interval > 30*time.Second {
+		return 30 * time.Second
+	}
+	return interval
+}
+
+// checkFailover switches to the next pool once the active one failed
+// Threshold times in a row. The log streams, the API poller and the proxy
+// all call it; the check and the switch happen under one lock and only for
+// the current generation, so they cannot switch twice for one outage.
+func (r *Wrapper) checkFailover(generation uint64) {
+	if !failoverEnabled(r.config.Miner) {
+		return
+	}
+	event, ok := r.state.recordFailoverDue(r.config.Miner.Pools, generation, r.config.Failover.Threshold, time.Now().UTC())
+	if !ok {
+		return
+	}
+	r.finishSwitch(event)
+}
+
+// switchPool makes pool index the active one and relaunches xmrig on it.
+func (r *Wrapper) switchPool(index int, reason string) {
+	event, ok := r.state.recordPoolSwitch(r.config.Miner.Pools, index, reason, time.Now().UTC())
+	if !ok {
+		return
+	}
+	r.finishSwitch(event)
+}
+
+// finishSwitch reports a recorded switch and stops xmrig so it relaunches on
+// the new pool.
+func (r *Wrapper) finishSwitch(event domain.XMRigPoolEvent) {
+	log.Printf("xmrig failover: %s -> %s (%s)", event.From, event.To, event.Reason)
+	if event.Reason == failoverReasonFailures {
+		observability.CaptureError(fmt.Errorf("xmrig pool %s failed %d times", event.From, event.Failures), map[string]string{
+			"component": "xmrig",
+			"operation": "pool_failover",
+		}, map[string]interface{}{
+			"from":       event.From,
+			"to":         event.To,
+			"last_error": event.Error,
+		})
+	}
+	r.stopProcess(domain.XMRigStopFailover)
+}
+
+// minerConfigForLaunch renders only the active pool when failover is on, so
+// the wrapper rather than xmrig decides which pool is used.
+func (r *Wrapper) minerConfigForLaunch() MinerConfig {
+	miner := r.baseMiner().withThreadLimit(r.currentThreadLimit())
+	if failoverEnabled(miner) {
+		active, _ := r.state.activePool()
+		miner.Pools = []PoolConfig{miner.Pools[active]}
+	}
+	return miner
+}
+
+func (s *state) activePool() (int, time.Time) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.failover.active, s.failover.activeSince
+}
+
+// recordFailoverDue switches to the next pool if generation is current and
+// the active pool reached threshold failures.
+func (s *state) recordFailoverDue(pools []PoolConfig, generation uint64, threshold int, at time.Time) (domain.XMRigPoolEvent, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if generation != s.failover.generation || s.failover.failures() < threshold {
+		return domain.XMRigPoolEvent{}, false
+	}
+	return s.recordPoolSwitchLocked(pools, (s.failover.active+1)%len(pools), failoverReasonFailures, at)
+}
+
+// recordPoolFailureLocked counts a connection or login error from the log.
+func (s *state) recordPoolFailureLocked(failure poolError) {
+	s.failover.logFailures++
+	s.failover.lastError = failure.pool + " " + failure.message
+}
+
+// recordPoolHealthyLocked resets the failure count after a job or an
+// accepted share.
+func (s *state) recordPoolHealthyLocked() {
+	s.failover.logFailures = 0
+	s.failover.apiBase = s.failover.apiFailures
+}
+
+func (s *state) recordAPIPoolFailuresLocked(failures int64) {
+	if failures < s.failover.apiBase {
+		s.failover.apiBase = 0
+	}
+	s.failover.apiFailures = failures
+}
+
+// resetPoolFailuresLocked starts counting afresh for a new process.
+func (s *state) resetPoolFailuresLocked() {
+	s.failover.logFailures = 0
+	s.failover.apiFailures = 0
+	s.failover.apiBase = 0
+}
+
+func (s *state) recordPoolSwitch(pools []PoolConfig, index int, reason string, at time.Time) (domain.XMRigPoolEvent, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.recordPoolSwitchLocked(pools, index, reason, at)
+}
+
+func (s *state) recordPoolSwitchLocked(pools []PoolConfig, index int, reason string, at time.Time) (domain.XMRigPoolEvent, bool) {
+	if index == s.failover.active {
+		return domain.XMRigPoolEvent{}, false
+	}
+	event := domain.XMRigPoolEvent{
+		Time:     at,
+		From:     pools[s.failover.active].URL,
+		To:       pools[index].URL,
+		Reason:   reason,
+		Failures: s.failover.failures(),
+		Error:    s.failover.lastError,
+	}
+	s.failover.active = index
+	s.failover.activeSince = at
+	s.failover.lastError = ""
+	s.failover.switches++
+	s.resetPoolFailuresLocked()
+	s.failover.generation++
+	s.failover.events = append(s.failover.events, event)
+	s.events.publish(event)
+	if len(s.failover.events) > maxFailoverEvents {
+		s.failover.events = s.failover.events[len(s.failover.events)-maxFailoverEvents:]
+	}
+	return event, true
+}
+
+func (s *state) failoverSnapshot(cfg FailoverConfig, pools []PoolConfig) *domain.XMRigFailoverStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	status := &domain.XMRigFailoverStatus{
+		ActivePool:          pools[s.failover.active].URL,
+		ActiveIndex:         s.failover.active,
+		Pools:               make([]string, 0, len(pools)),
+		Threshold:           cfg.Threshold,
+		RetryPrimarySeconds: int64(cfg.RetryPrimary / time.Second),
+		Failures:            s.failover.failures(),
+		LastError:           s.failover.lastError,
+		Switches:            s.failover.switches,
+		Events:              append([]domain.XMRigPoolEvent{}, s.failover.events...),
+	}
+	for _, pool := range pools {
+		status.Pools = append(status.Pools, pool.URL)
+	}
+	if !s.failover.activeSince.IsZero() {
+		since := s.failover.activeSince
+		status.ActiveSince = &since
+		if s.failover.active != 0 {
+			retry := since.Add(cfg.RetryPrimary)
+			status.NextPrimaryRetry = &retry
+		}
+	}
+	return status
+}
@@ -0,0 +1,67 @@
+package xmrig
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/restartfu/grid-node/internal/domain"
+)
+
+const connectError = "[2024-01-01 00:00:00.000]  net      pool.example:3333 connect error: \"connection refused\"\n"
+
+func newFailoverWrapper(t *testing.T) *Wrapper {
+	t.Helper()
+	miner := DefaultMinerConfig()
+	miner.Pools = append(miner.Pools, PoolConfig{URL: "fallback.example:443", User: "wallet", Coin: "monero", TLS: true})
+	return NewWrapper(io.Discard, Config{Miner: miner, Failover: FailoverConfig{Threshold: 3}})
+}
+
+func TestFailoverIgnoresStaleLinesAfterSwitch(t *testing.T) {
+	r := newFailoverWrapper(t)
+	switches, cancel := r.SubscribeEvents(domain.XMRigEventPoolSwitched)
+	defer cancel()
+	first := r.state.recordStart(time.Now().UTC(), 1)
+	r.streamLogs(strings.NewReader(strings.Repeat(connectError, 3)), domain.XMRigStreamStderr, first)
+	if active, _ := r.state.activePool(); active != 1 {
+		t.Fatalf("active pool = %d after 3 errors, want 1", active)
+	}
+
+	// The old process keeps logging errors until it exits; none of them may
+	// count against the fallback.
+	r.streamLogs(strings.NewReader(strings.Repeat(connectError, 6)), domain.XMRigStreamStderr, first)
+	status := r.Status().Failover
+	if status.ActiveIndex != 1 || status.Switches != 1 || status.Failures != 0 {
+		t.Fatalf("after stale errors: active %d, switches %d, failures %d; want 1, 1, 0",
+			status.ActiveIndex, status.Switches, status.Failures)
+	}
+
+	second := r.state.recordStart(time.Now().UTC(), 2)
+	r.streamLogs(strings.NewReader(strings.Repeat(connectError, 2)), domain.XMRigStreamStderr, second)
+	if status := r.Status().Failover; status.ActiveIndex != 1 || status.Failures != 2 {
+		t.Fatalf("new process: active %d, failures %d; want 1, 2", status.ActiveIndex, status.Failures)
+	}
+	r.streamLogs(strings.NewReader(connectError), domain.XMRigStreamStderr, second)
+	if status := r.Status().Failover; status.ActiveIndex != 0 || status.Switches != 2 {
+		t.Fatalf("new process: active %d, switches %d; want 0, 2", status.ActiveIndex, status.Switches)
+	}
+	if events, _ := drain(switches); len(events) != 2 {
+		t.Fatalf("published %d pool switches, want 2", len(events))
+	}
+}
+
+func TestFailoverIgnoresStaleAPIFailures(t *testing.T) {
+	r := newFailoverWrapper(t)
+	first := r.state.recordStart(time.Now().UTC(), 1)
+	if _, ok := r.state.recordPoolSwitch(r.config.Miner.Pools, 1, failoverReasonFailures, time.Now().UTC()); !ok {
+		t.Fatal("recordPoolSwitch() = false")
+	}
+	var summary apiSummary
+	summary.Connection.Failures = 10
+	r.state.recordAPISummary(summary, first)
+	r.checkFailover(first)
+	if status := r.Status().Failover; status.ActiveIndex != 1 || status.Failures != 0 {
+		t.Fatalf("active %d, failures %d after a stale API summary; want 1, 0", status.ActiveIndex, status.Failures)
+	}
+}
@@ -0,0 +1,80 @@
+package xmrig
+
+import (
+	"time"
+
+	"github.com/restartfu/grid-node/internal/domain"
+)
+
+type hashrateSample struct {
+	at       time.Time
+	hashrate float64
+}
+
+// hashrateRing keeps the most recent 10s hashrate samples in time order.
+type hashrateRing struct {
+	samples []hashrateSample
+	index   int
+	count   int
+}
+
+func newHashrateRing(capacity int) *hashrateRing {
+	return &hashrateRing{
+		samples: make([]hashrateSample, capacity),
+	}
+}
+
+func (r *hashrateRing) add(sample hashrateSample) {
+	r.samples[r.index] = sample
+	r.index = (r.index + 1) % len(r.samples)
+	if r.count < len(r.samples) {
+		r.count++
+	}
+}
+
+func (r *hashrateRing) at(i int) hashrateSample {
+	start := r.index - r.count
+	if start < 0 {
+		start += len(r.samples)
+	}
+	return r.samples[(start+i)%len(r.samples)]
+}
+
+// buckets downsamples [From, To) into Step-wide buckets aligned to Step.
+// Buckets without samples are returned with a zero sample count so gaps show.
+func (r *hashrateRing) buckets(q domain.XMRigHashrateQuery) domain.XMRigHashrateHistory {
+	first := q.From.Truncate(q.Step)
+	n := int((q.To.Sub(first) + q.Step - 1) / q.Step)
+	history := domain.XMRigHashrateHistory{
+		From:    first,
+		To:      q.To,
+		Step:    q.Step,
+		Buckets: make([]domain.XMRigHashrateBucket, n),
+	}
+	for i := range history.Buckets {
+		history.Buckets[i].Start = first.Add(time.Duration(i) * q.Step)
+	}
+	sums := make([]float64, n)
+	for i := 0; i < r.count; i++ {
+		sample := r.at(i)
+		if sample.at.Before(q.From) || !sample.at.Before(q.To) {
+			continue
+		}
+		index := int(sample.at.Sub(first) / q.Step)
+		bucket := &history.Buckets[index]
+		if bucket.Samples == 0 || sample.hashrate < bucket.MinHS {
+			bucket.MinHS = sample.hashrate
+		}
+		if bucket.Samples == 0 || sample.hashrate > bucket.MaxHS {
+			bucket.MaxHS = sample.hashrate
+		}
+		bucket.Samples++
+		sums[index] += sample.hashrate
+	}
+	for i := range history.Buckets {
+		if history.Buckets[i].Samples > 0 {
+			history.Buckets[i].AvgHS = sums[i] / float64(history.Buckets[i].Samples)
+		}
+	}
+	return history
+}
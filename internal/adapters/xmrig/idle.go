@@ -0,0 +1,173 @@
+package xmrig
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/restartfu/grid-node/internal/domain"
+	"github.com/restartfu/grid-node/internal/observability"
+	"github.com/restartfu/grid-node/internal/specs"
+)
+
+// idleState tracks load from everything but xmrig for idle-only mining.
+type idleState struct {
+	load      *float64
+	loadAt    time.Time
+	busySince time.Time
+	idleSince time.Time
+	holding   bool
+	heldAt    time.Time
+	pauses    int
+}
+
+// cpuSample is a /proc/stat reading plus xmrig's own CPU time at that moment.
+type cpuSample struct {
+	host  specs.CPUTimes
+	miner uint64
+	pid   int
+}
+
+// guardIdle pauses xmrig while other processes keep the host busy and
+// continues it once the host has been idle long enough.
+func (r *Wrapper) guardIdle(ctx context.Context) {
+	ticker := time.NewTicker(r.config.Idle.Interval)
+	defer ticker.Stop()
+	var previous *cpuSample
+	reported := false
+	for {
+		sample, err := r.sampleCPU()
+		if err != nil {
+			if !reported {
+				log.Printf("xmrig idle guard: %v", err)
+				observability.CaptureError(err, map[string]string{
+					"component": "xmrig",
+					"operation": "idle_sample",
+				}, nil)
+				reported = true
+			}
+		} else {
+			reported = false
+			if previous != nil {
+				if load, ok := nonMinerLoad(*previous, sample); ok {
+					r.checkIdle(load, time.Now().UTC())
+				}
+			}
+			previous = &sample
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Wrapper) sampleCPU() (cpuSample, error) {
+	host, err := specs.ReadCPUTimes()
+	if err != nil {
+		return cpuSample{}, err
+	}
+	sample := cpuSample{host: host, pid: r.pid()}
+	if sample.pid > 0 {
+		// xmrig may exit between the two reads; it then used no CPU since.
+		sample.miner, _ = specs.ReadProcessCPUTime(sample.pid)
+	}
+	return sample, nil
+}
+
+// nonMinerLoad is the share of all CPUs, in percent, that was busy with
+// something other than xmrig between two samples.
+func nonMinerLoad(previous, current cpuSample) (float64, bool) {
+	if current.host.Total <= previous.host.Total {
+		return 0, false
+	}
+	total := current.host.Total - previous.host.Total
+	busy := float64(current.host.Busy() - previous.host.Busy())
+	if current.pid > 0 && current.pid == previous.pid && current.miner >= previous.miner {
+		busy -= float64(current.miner - previous.miner)
+	}
+	if busy < 0 {
+		busy = 0
+	}
+	return busy / float64(total) * 100, true
+}
+
+func (r *Wrapper) checkIdle(load float64, now time.Time) {
+	cfg := r.config.Idle
+	idle := r.state.recordLoad(load, load >= cfg.Threshold, now)
+	switch {
+	case !idle.holding && !idle.busySince.IsZero() && now.Sub(idle.busySince) >= cfg.BusyFor:
+		log.Printf("xmrig idle guard: non-miner load %.1f%% above %.1f%% for %s, pausing", load, cfg.Threshold, now.Sub(idle.busySince).Round(time.Second))
+		r.state.recordIdleHold(true, now)
+		r.setHold(holdIdle, true)
+	case idle.holding && !idle.idleSince.IsZero() && now.Sub(idle.idleSince) >= cfg.IdleFor:
+		log.Printf("xmrig idle guard: host idle for %s, resuming", now.Sub(idle.idleSince).Round(time.Second))
+		r.state.recordIdleHold(false, now)
+		r.setHold(holdIdle, false)
+	case idle.holding:
+		r.applyHolds()
+	}
+}
+
+func (r *Wrapper) pid() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.process == nil || r.process.cmd.Process == nil {
+		return 0
+	}
+	return r.process.cmd.Process.Pid
+}
+
+// recordLoad stores a load sample and tracks how long the host has been
+// continuously busy or idle.
+func (s *state) recordLoad(load float64, busy bool, at time.Time) idleState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.idle.load = &load
+	s.idle.loadAt = at
+	if busy {
+		s.idle.idleSince = time.Time{}
+		if s.idle.busySince.IsZero() {
+			s.idle.busySince = at
+		}
+	} else {
+		s.idle.busySince = time.Time{}
+		if s.idle.idleSince.IsZero() {
+			s.idle.idleSince = at
+		}
+	}
+	return s.idle
+}
+
+func (s *state) recordIdleHold(holding bool, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.idle.holding = holding
+	if holding {
+		s.idle.heldAt = at
+		s.idle.pauses++
+	}
+}
+
+func (s *state) idleSnapshot(cfg IdleConfig) *domain.XMRigIdleStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	status := &domain.XMRigIdleStatus{
+		ThresholdPercent: cfg.Threshold,
+		BusySeconds:      int64(cfg.BusyFor / time.Second),
+		IdleSeconds:      int64(cfg.IdleFor / time.Second),
+		LoadPercent:      copyFloat(s.idle.load),
+		Holding:          s.idle.holding,
+		Pauses:           s.idle.pauses,
+	}
+	if s.idle.load != nil {
+		timestamp := s.idle.loadAt
+		status.LoadTime = &timestamp
+	}
+	if s.idle.holding {
+		timestamp := s.idle.heldAt
+		status.HeldSince = &timestamp
+	}
+	return status
+}
@@ -0,0 +1,527 @@
+package xmrig
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/restartfu/grid-node/internal/domain"
+	"github.com/restartfu/grid-node/internal/observability"
+)
+
+// rotatedTimeFormat is embedded in rotated segment names; it sorts
+// lexicographically in time order.
+const rotatedTimeFormat = "2006-01-02T15-04-05.000"
+
+// tailScanSize bounds how much of the active file is read to recover the
+// last sequence number on startup.
+const tailScanSize = 64 * 1024
+
+type LogFileConfig struct {
+	// Path of the active log file; empty disables the on-disk log.
+	Path string
+	// MaxSize in bytes and MaxAge of the active file before it is rotated.
+	MaxSize int64
+	MaxAge  time.Duration
+	// MaxBackups is how many gzipped rotated segments are kept.
+	MaxBackups int
+}
+
+// fileRecord is one JSON line in the on-disk log.
+type fileRecord struct {
+	Seq    uint64    `json:"seq"`
+	Time   time.Time `json:"time"`
+	Stream string    `json:"stream"`
+	Line   string    `json:"line"`
+}
+
+// logFile appends log lines as JSON lines and rotates them into gzipped
+// segments named <stem>-<rotation time><ext>.gz next to the active file; a
+// segment rotated within the same millisecond as an earlier one gets an _<n>
+// suffix after the time. Entries are queued by enqueue and written by a
+// single goroutine so disk I/O never runs under the state lock.
+type logFile struct {
+	cfg      LogFileConfig
+	dir      string
+	stem     string
+	ext      string
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	lastErr  string
+	compress sync.WaitGroup
+
+	mu      sync.Mutex
+	pending []domain.XMRigLogEntry
+	closing bool
+	wake    chan struct{}
+	done    chan struct{}
+}
+
+// openLogFile opens the active file for appending and returns the last
+// sequence number found on disk so numbering continues across restarts.
+func openLogFile(cfg LogFileConfig) (*logFile, uint64, error) {
+	dir := filepath.Dir(cfg.Path)
+	base := filepath.Base(cfg.Path)
+	ext := filepath.Ext(base)
+	f := &logFile{
+		cfg:  cfg,
+		dir:  dir,
+		stem: strings.TrimSuffix(base, ext),
+		ext:  ext,
+		wake: make(chan struct{}, 1),
+		done: make(chan struct{}),
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, 0, err
+	}
+	lastSeq, err := f.lastSeqOnDisk()
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := f.open(); err != nil {
+		return nil, 0, err
+	}
+	go f.writeLoop()
+	return f, lastSeq, nil
+}
+
+func (f *logFile) open() error {
+	file, err := os.OpenFile(f.cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = time.Now().UTC()
+	if first, ok := readFirstRecord(f.cfg.Path); ok {
+		f.openedAt = first.Time
+	}
+	return nil
+}
+
+// enqueue hands an entry to the writer goroutine, keeping sequence order.
+func (f *logFile) enqueue(entry domain.XMRigLogEntry) {
+	f.mu.Lock()
+	if f.closing {
+		f.mu.Unlock()
+		return
+	}
+	f.pending = append(f.pending, entry)
+	f.mu.Unlock()
+	select {
+	case f.wake <- struct{}{}:
+	default:
+	}
+}
+
+// writeLoop writes queued entries until close, draining the queue first.
+func (f *logFile) writeLoop() {
+	defer close(f.done)
+	for range f.wake {
+		f.mu.Lock()
+		entries, closing := f.pending, f.closing
+		f.pending = nil
+		f.mu.Unlock()
+		for _, entry := range entries {
+			f.write(entry)
+		}
+		if closing {
+			return
+		}
+	}
+}
+
+// write appends an entry, rotating first when the active file is too big or
+// too old. Errors are reported once per distinct failure.
+func (f *logFile) write(entry domain.XMRigLogEntry) {
+	data, err := json.Marshal(fileRecord{
+		Seq:    entry.Seq,
+		Time:   entry.Time,
+		Stream: entry.Stream,
+		Line:   entry.Line,
+	})
+	if err != nil {
+		f.report("encode", err)
+		return
+	}
+	data = append(data, '\n')
+	if f.file == nil || f.size+int64(len(data)) > f.cfg.MaxSize || entry.Time.Sub(f.openedAt) >= f.cfg.MaxAge {
+		if err := f.rotate(entry.Time); err != nil {
+			f.report("rotate", err)
+			return
+		}
+	}
+	n, err := f.file.Write(data)
+	f.size += int64(n)
+	if err != nil {
+		f.report("write", err)
+		return
+	}
+	f.lastErr = ""
+}
+
+func (f *logFile) rotate(at time.Time) error {
+	if f.file != nil {
+		if f.size == 0 {
+			f.openedAt = at
+			return nil
+		}
+		if err := f.file.Close(); err != nil {
+			return err
+		}
+		f.file = nil
+		rotated := f.rotatedName(at)
+		if err := os.Rename(f.cfg.Path, rotated); err != nil {
+			return err
+		}
+		f.compress.Add(1)
+		go func() {
+			defer f.compress.Done()
+			if err := compressSegment(rotated); err != nil {
+				log.Printf("xmrig log compress: %v", err)
+				observability.CaptureError(err, map[string]string{
+					"component": "xmrig",
+					"operation": "log_compress",
+				}, nil)
+			}
+			f.prune()
+		}()
+	}
+	return f.open()
+}
+
+// rotatedName picks a segment name no earlier segment uses, so a rename
+// never replaces one rotated in the same millisecond.
+func (f *logFile) rotatedName(at time.Time) string {
+	stamp := at.UTC().Format(rotatedTimeFormat)
+	for n := 0; ; n++ {
+		name := stamp
+		if n > 0 {
+			name = fmt.Sprintf("%s_%d", stamp, n)
+		}
+		path := filepath.Join(f.dir, fmt.Sprintf("%s-%s%s", f.stem, name, f.ext))
+		if !fileExists(path) && !fileExists(path+".gz") {
+			return path
+		}
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Lstat(path)
+	return !errors.Is(err, os.ErrNotExist)
+}
+
+// close writes the queued entries, closes the active file and waits for
+// pending compressions.
+func (f *logFile) close() {
+	f.mu.Lock()
+	if f.closing {
+		f.mu.Unlock()
+		return
+	}
+	f.closing = true
+	f.mu.Unlock()
+	f.wake <- struct{}{}
+	<-f.done
+	if f.file != nil {
+		if err := f.file.Close(); err != nil {
+			log.Printf("xmrig log file close: %v", err)
+		}
+		f.file = nil
+	}
+	f.compress.Wait()
+}
+
+func (f *logFile) report(operation string, err error) {
+	if err.Error() == f.lastErr {
+		return
+	}
+	f.lastErr = err.Error()
+	log.Printf("xmrig log file %s: %v", operation, err)
+	observability.CaptureError(err, map[string]string{
+		"component": "xmrig",
+		"operation": "log_file_" + operation,
+	}, map[string]interface{}{
+		"path": f.cfg.Path,
+	})
+}
+
+func compressSegment(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	tmp := path + ".gz.tmp"
+	dst, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(dst)
+	if _, err := io.Copy(zw, src); err != nil {
+		zw.Close()
+		dst.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		dst.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path+".gz"); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// prune removes the oldest rotated segments beyond MaxBackups.
+func (f *logFile) prune() {
+	segments, err := f.rotatedSegments()
+	if err != nil {
+		return
+	}
+	for len(segments) > f.cfg.MaxBackups {
+		_ = os.Remove(segments[0])
+		segments = segments[1:]
+	}
+}
+
+// rotatedSegments lists rotated files oldest first, preferring the gzipped
+// copy when a segment is mid-compression.
+func (f *logFile) rotatedSegments() ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(f.dir, f.stem+"-*"+f.ext+"*"))
+	if err != nil {
+		return nil, err
+	}
+	byName := map[string]string{}
+	for _, match := range matches {
+		name := filepath.Base(match)
+		switch {
+		case strings.HasSuffix(name, f.ext+".gz"):
+			byName[strings.TrimSuffix(name, ".gz")] = match
+		case strings.HasSuffix(name, f.ext):
+			if _, ok := byName[name]; !ok {
+				byName[name] = match
+			}
+		}
+	}
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	segments := make([]string, 0, len(names))
+	for _, name := range names {
+		segments = append(segments, byName[name])
+	}
+	return segments, nil
+}
+
+// rotatedAt parses the rotation time, i.e. the end of the segment, from its name.
+func (f *logFile) rotatedAt(path string) (time.Time, bool) {
+	name := strings.TrimSuffix(filepath.Base(path), ".gz")
+	name = strings.TrimSuffix(strings.TrimPrefix(name, f.stem+"-"), f.ext)
+	name, _, _ = strings.Cut(name, "_")
+	at, err := time.Parse(rotatedTimeFormat, name)
+	return at, err == nil
+}
+
+func (f *logFile) lastSeqOnDisk() (uint64, error) {
+	if record, ok := readLastRecord(f.cfg.Path); ok {
+		return record.Seq, nil
+	}
+	segments, err := f.rotatedSegments()
+	if err != nil {
+		return 0, err
+	}
+	for i := len(segments) - 1; i >= 0; i-- {
+		if record, ok := readLastRecord(segments[i]); ok {
+			return record.Seq, nil
+		}
+	}
+	return 0, nil
+}
+
+// query applies a log query to every segment on disk, oldest first.
+func (f *logFile) query(q domain.XMRigLogQuery) (domain.XMRigLogPage, error) {
+	segments, err := f.rotatedSegments()
+	if err != nil {
+		return domain.XMRigLogPage{}, err
+	}
+	segments = append(segments, f.cfg.Path)
+
+	page := domain.XMRigLogPage{
+		Logs:    []domain.XMRigLogEntry{},
+		NextSeq: q.SinceSeq,
+	}
+	for _, segment := range segments {
+		if first, ok := readFirstRecord(segment); ok {
+			page.OldestSeq = first.Seq
+			break
+		}
+	}
+
+	forward := q.SinceSeq > 0 || q.Since != nil
+	matches := make([]domain.XMRigLogEntry, 0)
+	for _, segment := range segments {
+		if end, ok := f.rotatedAt(segment); ok && q.Since != nil && end.Before(*q.Since) {
+			continue
+		}
+		done, err := scanSegment(segment, func(record fileRecord) bool {
+			if record.Seq <= q.SinceSeq {
+				return true
+			}
+			if q.Since != nil && record.Time.Before(*q.Since) {
+				return true
+			}
+			if q.Until != nil && !record.Time.Before(*q.Until) {
+				return !forward
+			}
+			matches = append(matches, domain.XMRigLogEntry{
+				Seq:    record.Seq,
+				Time:   record.Time,
+				Stream: record.Stream,
+				Line:   record.Line,
+			})
+			if forward {
+				return len(matches) <= q.Limit
+			}
+			if len(matches) > q.Limit {
+				page.HasMore = true
+				matches = matches[1:]
+			}
+			return true
+		})
+		if err != nil {
+			return domain.XMRigLogPage{}, err
+		}
+		if done {
+			break
+		}
+	}
+
+	if forward && len(matches) > q.Limit {
+		page.HasMore = true
+		matches = matches[:q.Limit]
+	}
+	page.Logs = append(page.Logs, matches...)
+	if len(page.Logs) > 0 {
+		page.NextSeq = page.Logs[len(page.Logs)-1].Seq
+	}
+	return page, nil
+}
+
+// scanSegment feeds every decodable record to fn until it returns false, in
+// which case done is true. Missing files are skipped since rotation and
+// pruning can race with readers.
+func scanSegment(path string, fn func(fileRecord) bool) (bool, error) {
+	reader, closeFn, err := openSegment(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer closeFn()
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var record fileRecord
+		if json.Unmarshal(scanner.Bytes(), &record) != nil {
+			continue
+		}
+		if !fn(record) {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+func openSegment(path string) (io.Reader, func(), error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !strings.HasSuffix(path, ".gz") {
+		return file, func() { file.Close() }, nil
+	}
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	return zr, func() {
+		zr.Close()
+		file.Close()
+	}, nil
+}
+
+func readFirstRecord(path string) (fileRecord, bool) {
+	var first fileRecord
+	found := false
+	_, _ = scanSegment(path, func(record fileRecord) bool {
+		first, found = record, true
+		return false
+	})
+	return first, found
+}
+
+// readLastRecord reads the final record, only looking at the tail of
+// uncompressed files.
+func readLastRecord(path string) (fileRecord, bool) {
+	var last fileRecord
+	found := false
+	if strings.HasSuffix(path, ".gz") {
+		_, _ = scanSegment(path, func(record fileRecord) bool {
+			last, found = record, true
+			return true
+		})
+		return last, found
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return last, false
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return last, false
+	}
+	offset := info.Size() - tailScanSize
+	if offset < 0 {
+		offset = 0
+	}
+	buf := make([]byte, info.Size()-offset)
+	if _, err := file.ReadAt(buf, offset); err != nil && err != io.EOF {
+		return last, false
+	}
+	lines := bytes.Split(bytes.TrimRight(buf, "\n"), []byte("\n"))
+	for i := len(lines) - 1; i >= 0; i-- {
+		if json.Unmarshal(lines[i], &last) == nil {
+			return last, true
+		}
+	}
+	return last, false
+}
@@ -0,0 +1,98 @@
+package xmrig
+
+import "github.com/restartfu/grid-node/internal/domain"
+
+// logRing is a fixed-capacity buffer of log lines. Every line gets the next
+// sequence number, so the sequence of the oldest retained line is always
+// lastSeq-count+1 and cursors map to positions without searching.
+type logRing struct {
+	entries []domain.XMRigLogEntry
+	index   int
+	count   int
+	lastSeq uint64
+}
+
+func newLogRing(capacity int) *logRing {
+	return &logRing{
+		entries: make([]domain.XMRigLogEntry, capacity),
+	}
+}
+
+func (r *logRing) add(entry domain.XMRigLogEntry) domain.XMRigLogEntry {
+	r.lastSeq++
+	entry.Seq = r.lastSeq
+	r.entries[r.index] = entry
+	r.index = (r.index + 1) % len(r.entries)
+	if r.count < len(r.entries) {
+		r.count++
+	}
+	return entry
+}
+
+func (r *logRing) oldestSeq() uint64 {
+	if r.count == 0 {
+		return r.lastSeq + 1
+	}
+	return r.lastSeq - uint64(r.count) + 1
+}
+
+// at returns the i-th retained line, oldest first.
+func (r *logRing) at(i int) domain.XMRigLogEntry {
+	start := r.index - r.count
+	if start < 0 {
+		start += len(r.entries)
+	}
+	return r.entries[(start+i)%len(r.entries)]
+}
+
+func (r *logRing) last(count int) []domain.XMRigLogEntry {
+	if count > r.count {
+		count = r.count
+	}
+	logs := make([]domain.XMRigLogEntry, 0, count)
+	for i := r.count - count; i < r.count; i++ {
+		logs = append(logs, r.at(i))
+	}
+	return logs
+}
+
+// query pages forward from a cursor (SinceSeq or Since) and otherwise returns
+// the newest matching lines.
+func (r *logRing) query(q domain.XMRigLogQuery) domain.XMRigLogPage {
+	page := domain.XMRigLogPage{
+		Logs:      []domain.XMRigLogEntry{},
+		OldestSeq: r.oldestSeq(),
+		NextSeq:   q.SinceSeq,
+	}
+	first := 0
+	if q.SinceSeq >= page.OldestSeq {
+		first = int(q.SinceSeq - page.OldestSeq + 1)
+	}
+	matches := make([]domain.XMRigLogEntry, 0)
+	for i := first; i < r.count; i++ {
+		entry := r.at(i)
+		if q.Since != nil && entry.Time.Before(*q.Since) {
+			continue
+		}
+		if q.Until != nil && !entry.Time.Before(*q.Until) {
+			continue
+		}
+		matches = append(matches, entry)
+	}
+
+	if len(matches) > q.Limit {
+		page.HasMore = true
+		if q.SinceSeq > 0 || q.Since != nil {
+			matches = matches[:q.Limit]
+		} else {
+			matches = matches[len(matches)-q.Limit:]
+		}
+	}
+	page.Logs = append(page.Logs, matches...)
+	if len(page.Logs) > 0 {
+		page.NextSeq = page.Logs[len(page.Logs)-1].Seq
+	} else if q.SinceSeq == 0 && q.Since == nil {
+		page.NextSeq = r.lastSeq
+	}
+	return page
+}
@@ -0,0 +1,356 @@
+package xmrig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+var randomXModes = []string{"auto", "fast", "light"}
+
+// MinerConfig is the typed xmrig configuration rendered to config.json. JSON
+// tags describe grid-node's own config file, not xmrig's format.
+type MinerConfig struct {
+	Pools   []PoolConfig  `json:"pools"`
+	CPU     CPUConfig     `json:"cpu"`
+	RandomX RandomXConfig `json:"randomx"`
+	// PrintTime is the hashrate report interval in seconds.
+	PrintTime int `json:"print_time"`
+}
+
+type PoolConfig struct {
+	URL       string `json:"url"`
+	User      string `json:"user"`
+	Pass      string `json:"pass"`
+	Algo      string `json:"algo"`
+	Coin      string `json:"coin"`
+	RigID     string `json:"rig_id"`
+	TLS       bool   `json:"tls"`
+	Keepalive bool   `json:"keepalive"`
+	Nicehash  bool   `json:"nicehash"`
+}
+
+type CPUConfig struct {
+	// Threads is the number of mining threads; 0 lets xmrig decide.
+	Threads int `json:"threads"`
+	// Affinity pins thread i to CPU Affinity[i]. When both are set its
+	// length must equal Threads.
+	Affinity []int `json:"affinity"`
+	// Priority is the thread priority from 0 (idle) to 5 (highest).
+	Priority     int  `json:"priority"`
+	HugePages    bool `json:"huge_pages"`
+	HugePagesJIT bool `json:"huge_pages_jit"`
+	// MaxThreadsHint limits automatic thread selection to a percentage of
+	// CPUs; 0 leaves it at 100.
+	MaxThreadsHint int  `json:"max_threads_hint"`
+	Yield          bool `json:"yield"`
+}
+
+type RandomXConfig struct {
+	// Mode is auto, fast (2 GB dataset) or light (256 MB cache only).
+	Mode       string `json:"mode"`
+	OneGBPages bool   `json:"1gb_pages"`
+	// InitThreads is the number of dataset init threads; -1 uses all CPUs.
+	InitThreads int  `json:"init_threads"`
+	NUMA        bool `json:"numa"`
+	WrMSR       bool `json:"wrmsr"`
+}
+
+// DefaultMinerConfig matches the flags grid-node used to pass on the command
+// line.
+func DefaultMinerConfig() MinerConfig {
+	return MinerConfig{
+		Pools: []PoolConfig{{
+			URL:  "tokyo:3333",
+			User: "%H",
+			Pass: "%H",
+			Algo: "rx/monero",
+		}},
+		CPU: CPUConfig{
+			Priority:  5,
+			HugePages: true,
+			Yield:     true,
+		},
+		RandomX: RandomXConfig{
+			Mode:        defaultRandomXMode,
+			OneGBPages:  true,
+			InitThreads: -1,
+			NUMA:        true,
+			WrMSR:       true,
+		},
+		PrintTime: 5,
+	}
+}
+
+// LoadMinerConfig reads a config file on top of the defaults; a list given in
+// the file replaces the default list.
+func LoadMinerConfig(path string) (MinerConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return MinerConfig{}, err
+	}
+	cfg := DefaultMinerConfig()
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cfg); err != nil {
+		return MinerConfig{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// ValidationError is a problem with a single config field.
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return "invalid xmrig config: " + strings.Join(messages, "; ")
+}
+
+// Validate rejects values and combinations xmrig would ignore or fail on.
+func (c MinerConfig) Validate() error {
+	var errs ValidationErrors
+	add := func(field, format string, args ...interface{}) {
+		errs = append(errs, ValidationError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(c.Pools) == 0 {
+		add("pools", "at least one pool is required")
+	}
+	for i, pool := range c.Pools {
+		field := "pools[" + strconv.Itoa(i) + "]"
+		if pool.URL == "" {
+			add(field+".url", "is required")
+		} else if _, port, err := net.SplitHostPort(poolAddress(pool.URL)); err != nil || port == "" {
+			add(field+".url", "must be host:port, got %q", pool.URL)
+		}
+		if pool.Algo == "" && pool.Coin == "" {
+			add(field+".algo", "algo or coin is required")
+		}
+	}
+
+	cpus := runtime.NumCPU()
+	if c.CPU.Threads < 0 {
+		add("cpu.threads", "must not be negative")
+	}
+	if c.CPU.Threads > 0 && len(c.CPU.Affinity) > 0 && len(c.CPU.Affinity) != c.CPU.Threads {
+		add("cpu.affinity", "has %d entries but cpu.threads is %d", len(c.CPU.Affinity), c.CPU.Threads)
+	}
+	for i, cpu := range c.CPU.Affinity {
+		if cpu < -1 || cpu >= cpus {
+			add("cpu.affinity["+strconv.Itoa(i)+"]", "CPU %d does not exist, expected -1 to %d", cpu, cpus-1)
+		}
+	}
+	if c.CPU.Priority < 0 || c.CPU.Priority > 5 {
+		add("cpu.priority", "must be between 0 and 5")
+	}
+	if c.CPU.MaxThreadsHint < 0 || c.CPU.MaxThreadsHint > 100 {
+		add("cpu.max_threads_hint", "must be between 0 and 100 (0 = unset)")
+	}
+	if c.CPU.MaxThreadsHint > 0 && (c.CPU.Threads > 0 || len(c.CPU.Affinity) > 0) {
+		add("cpu.max_threads_hint", "only applies when cpu.threads and cpu.affinity are unset")
+	}
+	if c.CPU.HugePagesJIT && !c.CPU.HugePages {
+		add("cpu.huge_pages_jit", "requires cpu.huge_pages")
+	}
+
+	if !contains(randomXModes, c.RandomX.Mode) {
+		add("randomx.mode", "must be one of %s", strings.Join(randomXModes, ", "))
+	}
+	if c.RandomX.OneGBPages && c.RandomX.Mode == "light" {
+		add("randomx.1gb_pages", "has no effect in light mode, which has no dataset")
+	}
+	if c.RandomX.OneGBPages && !c.CPU.HugePages {
+		add("randomx.1gb_pages", "requires cpu.huge_pages")
+	}
+	if c.RandomX.InitThreads < -1 || c.RandomX.InitThreads == 0 || c.RandomX.InitThreads > cpus {
+		add("randomx.init_threads", "must be -1 or between 1 and %d", cpus)
+	}
+
+	if c.PrintTime < 0 {
+		add("print_time", "must not be negative")
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// withThreadLimit caps the thread count, keeping the first pinned CPUs.
+func (c MinerConfig) withThreadLimit(threads int) MinerConfig {
+	if threads <= 0 {
+		return c
+	}
+	if c.CPU.Threads == 0 || c.CPU.Threads > threads {
+		c.CPU.Threads = threads
+	}
+	if len(c.CPU.Affinity) > c.CPU.Threads {
+		c.CPU.Affinity = append([]int(nil), c.CPU.Affinity[:c.CPU.Threads]...)
+	}
+	c.CPU.MaxThreadsHint = 0
+	return c
+}
+
+// render produces xmrig's config.json. The API section is enabled only when
+// an endpoint is given.
+func (c MinerConfig) render(api *apiEndpoint) ([]byte, error) {
+	pools := make([]map[string]interface{}, 0, len(c.Pools))
+	for _, pool := range c.Pools {
+		rendered := map[string]interface{}{
+			"url":       pool.URL,
+			"user":      pool.User,
+			"pass":      pool.Pass,
+			"keepalive": pool.Keepalive,
+			"tls":       pool.TLS,
+			"nicehash":  pool.Nicehash,
+			"algo":      nullIfEmpty(pool.Algo),
+			"coin":      nullIfEmpty(pool.Coin),
+			"rig-id":    nullIfEmpty(pool.RigID),
+		}
+		pools = append(pools, rendered)
+	}
+
+	maxThreadsHint := c.CPU.MaxThreadsHint
+	if maxThreadsHint == 0 {
+		maxThreadsHint = 100
+	}
+	cpu := map[string]interface{}{
+		"enabled":          true,
+		"huge-pages":       c.CPU.HugePages,
+		"huge-pages-jit":   c.CPU.HugePagesJIT,
+		"priority":         c.CPU.Priority,
+		"max-threads-hint": maxThreadsHint,
+		"yield":            c.CPU.Yield,
+	}
+	if profile := c.CPU.profile(); profile != nil {
+		// "*" applies to every algorithm family.
+		cpu["*"] = profile
+	}
+
+	http := map[string]interface{}{"enabled": false}
+	if api != nil {
+		http = map[string]interface{}{
+			"enabled":      true,
+			"host":         apiHost,
+			"port":         api.port,
+			"access-token": api.token,
+			"restricted":   true,
+		}
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"autosave":   false,
+		"background": false,
+		"colors":     false,
+		"print-time": c.PrintTime,
+		"pools":      pools,
+		"cpu":        cpu,
+		"randomx": map[string]interface{}{
+			"init":      c.RandomX.InitThreads,
+			"mode":      c.RandomX.Mode,
+			"1gb-pages": c.RandomX.OneGBPages,
+			"numa":      c.RandomX.NUMA,
+			"wrmsr":     c.RandomX.WrMSR,
+		},
+		"http": http,
+	}, "", "  ")
+}
+
+// profile lists one entry per thread, the pinned CPU or -1 for none, or nil
+// to let xmrig pick threads itself.
+func (c CPUConfig) profile() []int {
+	if len(c.Affinity) > 0 {
+		return append([]int(nil), c.Affinity...)
+	}
+	if c.Threads == 0 {
+		return nil
+	}
+	profile := make([]int, c.Threads)
+	for i := range profile {
+		profile[i] = -1
+	}
+	return profile
+}
+
+// writeMinerConfig writes the rendered config readable only by grid-node, as
+// it carries the API token. The temporary file gets a random name so nobody
+// can plant or pre-open it.
+func writeMinerConfig(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := privateDir(dir); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// privateDir creates dir or checks an existing one: it must be a real
+// directory owned by grid-node's user that nobody else can write to, so the
+// files in it cannot be swapped before xmrig reads them.
+func privateDir(dir string) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return err
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !info.IsDir() || !ok || int(stat.Uid) != os.Geteuid() {
+		return fmt.Errorf("%s is not a directory owned by uid %d", dir, os.Geteuid())
+	}
+	if info.Mode().Perm()&0o022 != 0 {
+		return fmt.Errorf("%s is writable by other users", dir)
+	}
+	return nil
+}
+
+func nullIfEmpty(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
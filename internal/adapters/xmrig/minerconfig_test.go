@@ -0,0 +1,90 @@
+package xmrig
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestMinerConfigValidate(t *testing.T) {
+	cpus := runtime.NumCPU()
+	tests := []struct {
+		name   string
+		modify func(*MinerConfig)
+		field  string
+	}{
+		{"no pools", func(c *MinerConfig) { c.Pools = nil }, "pools"},
+		{"missing url", func(c *MinerConfig) { c.Pools[0].URL = "" }, "pools[0].url"},
+		{"url without port", func(c *MinerConfig) { c.Pools[0].URL = "tokyo" }, "pools[0].url"},
+		{"no algo or coin", func(c *MinerConfig) { c.Pools[0].Algo = "" }, "pools[0].algo"},
+		{"negative threads", func(c *MinerConfig) { c.CPU.Threads = -1 }, "cpu.threads"},
+		{"affinity length", func(c *MinerConfig) {
+			c.CPU.Threads = 2
+			c.CPU.Affinity = []int{0}
+		}, "cpu.affinity"},
+		{"affinity cpu", func(c *MinerConfig) { c.CPU.Affinity = []int{cpus} }, "cpu.affinity[0]"},
+		{"priority", func(c *MinerConfig) { c.CPU.Priority = 6 }, "cpu.priority"},
+		{"max threads hint range", func(c *MinerConfig) { c.CPU.MaxThreadsHint = 101 }, "cpu.max_threads_hint"},
+		{"max threads hint with threads", func(c *MinerConfig) {
+			c.CPU.MaxThreadsHint = 50
+			c.CPU.Threads = 1
+		}, "cpu.max_threads_hint"},
+		{"huge pages jit", func(c *MinerConfig) {
+			c.CPU.HugePagesJIT = true
+			c.CPU.HugePages = false
+			c.RandomX.OneGBPages = false
+		}, "cpu.huge_pages_jit"},
+		{"randomx mode", func(c *MinerConfig) { c.RandomX.Mode = "turbo" }, "randomx.mode"},
+		{"1gb pages in light mode", func(c *MinerConfig) { c.RandomX.Mode = "light" }, "randomx.1gb_pages"},
+		{"1gb pages without huge pages", func(c *MinerConfig) { c.CPU.HugePages = false }, "randomx.1gb_pages"},
+		{"init threads zero", func(c *MinerConfig) { c.RandomX.InitThreads = 0 }, "randomx.init_threads"},
+		{"init threads too many", func(c *MinerConfig) { c.RandomX.InitThreads = cpus + 1 }, "randomx.init_threads"},
+		{"print time", func(c *MinerConfig) { c.PrintTime = -1 }, "print_time"},
+	}
+	if err := DefaultMinerConfig().Validate(); err != nil {
+		t.Fatalf("default config: %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultMinerConfig()
+			tt.modify(&cfg)
+			var errs ValidationErrors
+			if !errors.As(cfg.Validate(), &errs) {
+				t.Fatalf("Validate() = %v, want ValidationErrors", cfg.Validate())
+			}
+			if len(errs) != 1 || errs[0].Field != tt.field {
+				t.Fatalf("Validate() = %v, want one error on %s", errs, tt.field)
+			}
+		})
+	}
+}
+
+func TestMinerConfigValidateAcceptsUnsetHint(t *testing.T) {
+	cfg := DefaultMinerConfig()
+	cfg.CPU.MaxThreadsHint = 0
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil for an unset hint", err)
+	}
+}
+
+func TestMinerConfigRender(t *testing.T) {
+	cfg := DefaultMinerConfig()
+	cfg.Pools = append(cfg.Pools, PoolConfig{URL: "fallback:443", User: "wallet", Coin: "monero", TLS: true, Keepalive: true})
+	cfg.CPU.Threads = 2
+	cfg.CPU.Affinity = []int{0, -1}
+	got, err := cfg.render(&apiEndpoint{port: 18081, token: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join("testdata", "config.golden.json")
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, bytes.TrimSuffix(want, []byte("\n"))) {
+		t.Errorf("render() differs from %s:\n%s", golden, got)
+	}
+}
@@ -4,38 +4,200 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var ansiRegex = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
 
-// parseHashrateFromLog parses a 10s hashrate line from xmrig output.
-func parseHashrateFromLog(line string) (float64, bool) {
+var shareRegex = regexp.MustCompile(`\b(accepted|rejected) \((\d+)/(\d+)\) diff (\d+)(?: "([^"]*)")? \((\d+) ms\)`)
+
+var jobRegex = regexp.MustCompile(`\bnew job from (\S+) diff (\d+) algo (\S+)(?: height (\d+))?`)
+
+var poolRegex = regexp.MustCompile(`\buse pool (\S+)`)
+
+var poolErrorRegex = regexp.MustCompile(`(?i)(\S+:\d+)\s+((?:connect|dns|read|write|login|tls|socks5?) error\b.*)$`)
+
+// lineInfo holds everything recognised in a single xmrig log line.
+type lineInfo struct {
+	hashrate *hashrateInfo
+	share    *shareResult
+	job      *jobInfo
+	// poolConnected is the pool from a "use pool" line.
+	poolConnected string
+	// poolError is set for connection and login errors.
+	poolError *poolError
+	randomx   *randomxLine
+}
+
+// hashrateInfo holds the 10s, 60s and 15m averages and the highest 10s
+// figure in H/s. Values xmrig reports as n/a are nil.
+type hashrateInfo struct {
+	tenSec     *float64
+	sixtySec   *float64
+	fifteenMin *float64
+	max        *float64
+}
+
+type shareResult struct {
+	accepted   bool
+	stale      bool
+	acceptedN  uint64
+	rejectedN  uint64
+	difficulty uint64
+	latency    time.Duration
+	reason     string
+}
+
+type poolError struct {
+	pool    string
+	message string
+}
+
+type jobInfo struct {
+	pool       string
+	difficulty uint64
+	algo       string
+	height     uint64
+}
+
+func parseLine(line string) lineInfo {
+	line = ansiRegex.ReplaceAllString(line, "")
+	var info lineInfo
+	if hashrate, ok := parseHashrateFromLog(line); ok {
+		info.hashrate = &hashrate
+	}
+	if share, ok := parseShareFromLog(line); ok {
+		info.share = &share
+	}
+	if job, ok := parseJobFromLog(line); ok {
+		info.job = &job
+	}
+	if match := poolRegex.FindStringSubmatch(line); match != nil {
+		info.poolConnected = match[1]
+	}
+	if failure, ok := parsePoolErrorFromLog(line); ok {
+		info.poolError = &failure
+	}
+	if randomx, ok := parseRandomXLine(line); ok {
+		info.randomx = &randomx
+	}
+	return info
+}
+
+// parseShareFromLog parses "accepted (12/1) diff 120001 (45 ms)" and the
+// matching "rejected" line, which also carries the pool's reason.
+func parseShareFromLog(line string) (shareResult, bool) {
+	match := shareRegex.FindStringSubmatch(line)
+	if match == nil {
+		return shareResult{}, false
+	}
+	acceptedN, _ := strconv.ParseUint(match[2], 10, 64)
+	rejectedN, _ := strconv.ParseUint(match[3], 10, 64)
+	difficulty, _ := strconv.ParseUint(match[4], 10, 64)
+	latency, _ := strconv.ParseInt(match[6], 10, 64)
+	result := shareResult{
+		accepted:   match[1] == "accepted",
+		acceptedN:  acceptedN,
+		rejectedN:  rejectedN,
+		difficulty: difficulty,
+		latency:    time.Duration(latency) * time.Millisecond,
+		reason:     match[5],
+	}
+	result.stale = !result.accepted && isStaleReason(result.reason)
+	return result, true
+}
+
+// isStaleReason reports whether a rejection was for work on an outdated job.
+func isStaleReason(reason string) bool {
+	lower := strings.ToLower(reason)
+	for _, marker := range []string{"stale", "job not found", "expired", "outdated"} {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseJobFromLog parses "new job from host:port diff 120001 algo rx/0 height 3000000".
+func parseJobFromLog(line string) (jobInfo, bool) {
+	match := jobRegex.FindStringSubmatch(line)
+	if match == nil {
+		return jobInfo{}, false
+	}
+	difficulty, _ := strconv.ParseUint(match[2], 10, 64)
+	height, _ := strconv.ParseUint(match[4], 10, 64)
+	return jobInfo{
+		pool:       match[1],
+		difficulty: difficulty,
+		algo:       match[3],
+		height:     height,
+	}, true
+}
+
+// parsePoolErrorFromLog parses `host:port connect error: "connection refused"`
+// and the DNS, read, write, TLS and login variants.
+func parsePoolErrorFromLog(line string) (poolError, bool) {
+	match := poolErrorRegex.FindStringSubmatch(line)
+	if match == nil {
+		return poolError{}, false
+	}
+	return poolError{pool: match[1], message: strings.TrimSpace(match[2])}, true
+}
+
+// parseHashrateFromLog parses
+// "speed 10s/60s/15m 1234.5 1230.1 n/a H/s max 1300.2 H/s".
+func parseHashrateFromLog(line string) (hashrateInfo, bool) {
 	line = ansiRegex.ReplaceAllString(line, "")
 	lower := strings.ToLower(line)
 	if !strings.Contains(lower, "speed") {
-		return 0, false
+		return hashrateInfo{}, false
 	}
 
 	fields := strings.Fields(line)
-	for i := 0; i+2 < len(fields); i++ {
+	for i := 0; i+5 < len(fields); i++ {
 		if !strings.EqualFold(fields[i], "speed") {
 			continue
 		}
 		if !strings.EqualFold(fields[i+1], "10s/60s/15m") {
 			continue
 		}
-		value, err := strconv.ParseFloat(fields[i+2], 64)
-		if err != nil {
-			return 0, false
+		unit := fields[i+5]
+		if !strings.HasSuffix(strings.ToLower(unit), "h/s") {
+			return hashrateInfo{}, false
+		}
+		var values [3]*float64
+		for j := range values {
+			value, ok := parseHashrateValue(fields[i+2+j], unit)
+			if !ok {
+				return hashrateInfo{}, false
+			}
+			values[j] = value
 		}
-		unit := ""
-		last := fields[len(fields)-1]
-		if strings.HasSuffix(strings.ToLower(last), "h/s") {
-			unit = last
+		info := hashrateInfo{
+			tenSec:     values[0],
+			sixtySec:   values[1],
+			fifteenMin: values[2],
 		}
-		return scaleHashrate(value, unit), true
+		rest := fields[i+6:]
+		if len(rest) >= 3 && strings.EqualFold(rest[0], "max") {
+			info.max, _ = parseHashrateValue(rest[1], rest[2])
+		}
+		return info, true
+	}
+	return hashrateInfo{}, false
+}
+
+// parseHashrateValue returns nil for n/a and false for anything unparsable.
+func parseHashrateValue(field, unit string) (*float64, bool) {
+	if strings.EqualFold(field, "n/a") {
+		return nil, true
+	}
+	value, err := strconv.ParseFloat(field, 64)
+	if err != nil {
+		return nil, false
 	}
-	return 0, false
+	value = scaleHashrate(value, unit)
+	return &value, true
 }
 
 // scaleHashrate converts the value into H/s.
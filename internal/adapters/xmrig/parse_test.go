@@ -0,0 +1,207 @@
+package xmrig
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestParseShareFromLog(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want *shareResult
+	}{
+		{
+			"accepted",
+			"[2024-05-12 14:03:45.120]  cpu      accepted (12/1) diff 120001 (52 ms)",
+			&shareResult{accepted: true, acceptedN: 12, rejectedN: 1, difficulty: 120001, latency: 52 * time.Millisecond},
+		},
+		{
+			"colored",
+			"[2024-05-12 14:03:45.120]  \x1b[0;36mcpu     \x1b[0m \x1b[1;32maccepted\x1b[0m (12/1) diff \x1b[1;37m120001\x1b[0m \x1b[1;30m(52 ms)\x1b[0m",
+			&shareResult{accepted: true, acceptedN: 12, rejectedN: 1, difficulty: 120001, latency: 52 * time.Millisecond},
+		},
+		{
+			"rejected",
+			`[2024-05-12 14:05:02.771]  cpu      rejected (12/2) diff 120001 "Low difficulty share" (48 ms)`,
+			&shareResult{acceptedN: 12, rejectedN: 2, difficulty: 120001, latency: 48 * time.Millisecond, reason: "Low difficulty share"},
+		},
+		{
+			"stale",
+			`[2024-05-12 14:05:02.771]  cpu      rejected (12/3) diff 120001 "Block expired" (61 ms)`,
+			&shareResult{stale: true, acceptedN: 12, rejectedN: 3, difficulty: 120001, latency: 61 * time.Millisecond, reason: "Block expired"},
+		},
+		{
+			"stale job not found",
+			`[2024-05-12 14:05:02.771]  cpu      rejected (12/4) diff 120001 "Job not found" (39 ms)`,
+			&shareResult{stale: true, acceptedN: 12, rejectedN: 4, difficulty: 120001, latency: 39 * time.Millisecond, reason: "Job not found"},
+		},
+		{"truncated", "[2024-05-12 14:03:45.120]  cpu      accepted (12/1) diff 120001 (52", nil},
+		{"speed line", "[2024-05-12 14:04:11.402]  miner    speed 10s/60s/15m 6512.3 6498.7 n/a H/s max 6530.1 H/s", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseLine(tt.line).share
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("share = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseJobFromLog(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want *jobInfo
+	}{
+		{
+			"with height",
+			"[2024-05-12 14:03:11.483]  net      new job from pool.supportxmr.com:443 diff 120001 algo rx/0 height 3145678 (3 tx)",
+			&jobInfo{pool: "pool.supportxmr.com:443", difficulty: 120001, algo: "rx/0", height: 3145678},
+		},
+		{
+			"without height",
+			"[2024-05-12 14:03:11.483]  net      new job from 127.0.0.1:3333 diff 1000 algo rx/wow",
+			&jobInfo{pool: "127.0.0.1:3333", difficulty: 1000, algo: "rx/wow"},
+		},
+		{
+			"colored",
+			"[2024-05-12 14:03:11.483]  \x1b[1;44;37m net     \x1b[0m \x1b[1;35mnew job\x1b[0m from \x1b[1;37mpool.supportxmr.com:443\x1b[0m diff \x1b[1;37m120001\x1b[0m algo \x1b[1;37mrx/0\x1b[0m height \x1b[1;37m3145678\x1b[0m",
+			&jobInfo{pool: "pool.supportxmr.com:443", difficulty: 120001, algo: "rx/0", height: 3145678},
+		},
+		{"truncated", "[2024-05-12 14:03:11.483]  net      new job from pool.supportxmr.com:443 diff 120001", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseLine(tt.line).job
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("job = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePoolLines(t *testing.T) {
+	tests := []struct {
+		name      string
+		line      string
+		connected string
+		failure   *poolError
+	}{
+		{
+			name:      "use pool",
+			line:      "[2024-05-12 14:03:11.482]  net      use pool pool.supportxmr.com:443 TLSv1.3 104.243.33.118",
+			connected: "pool.supportxmr.com:443",
+		},
+		{
+			name:    "connect error",
+			line:    `[2024-05-12 14:02:50.101]  net      pool.supportxmr.com:443 connect error: "connection refused"`,
+			failure: &poolError{pool: "pool.supportxmr.com:443", message: `connect error: "connection refused"`},
+		},
+		{
+			name:    "dns error",
+			line:    `[2024-05-12 14:02:50.101]  net      pool.supportxmr.com:443 DNS error: "unknown node or service"`,
+			failure: &poolError{pool: "pool.supportxmr.com:443", message: `DNS error: "unknown node or service"`},
+		},
+		{
+			name:    "read error",
+			line:    `[2024-05-12 14:02:50.101]  net      127.0.0.1:3333 read error: "end of file"`,
+			failure: &poolError{pool: "127.0.0.1:3333", message: `read error: "end of file"`},
+		},
+		{
+			name:    "tls error",
+			line:    `[2024-05-12 14:02:50.101]  net      pool.supportxmr.com:443 TLS error: "certificate verify failed"`,
+			failure: &poolError{pool: "pool.supportxmr.com:443", message: `TLS error: "certificate verify failed"`},
+		},
+		{
+			name:    "login error",
+			line:    `[2024-05-12 14:02:50.101]  net      pool.supportxmr.com:443 login error code: 6`,
+			failure: &poolError{pool: "pool.supportxmr.com:443", message: "login error code: 6"},
+		},
+		{
+			name: "no pool address",
+			line: `[2024-05-12 14:02:50.101]  net      connect error: "connection refused"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := parseLine(tt.line)
+			if info.poolConnected != tt.connected || !reflect.DeepEqual(info.poolError, tt.failure) {
+				t.Fatalf("connected %q, error %+v; want %q, %+v", info.poolConnected, info.poolError, tt.connected, tt.failure)
+			}
+		})
+	}
+}
+
+func TestParseHashrateFromLog(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	tests := []struct {
+		name string
+		line string
+		want *hashrateInfo
+	}{
+		{
+			"all averages",
+			"[2024-05-12 14:19:11.402]  miner    speed 10s/60s/15m 6512.3 6498.7 6471.0 H/s max 6530.1 H/s",
+			&hashrateInfo{tenSec: f(6512.3), sixtySec: f(6498.7), fifteenMin: f(6471), max: f(6530.1)},
+		},
+		{
+			"15m not yet available",
+			"[2024-05-12 14:04:11.402]  miner    speed 10s/60s/15m 6512.3 6498.7 n/a H/s max 6530.1 H/s",
+			&hashrateInfo{tenSec: f(6512.3), sixtySec: f(6498.7), max: f(6530.1)},
+		},
+		{
+			"right after start",
+			"[2024-05-12 14:03:21.402]  miner    speed 10s/60s/15m n/a n/a n/a H/s max n/a H/s",
+			&hashrateInfo{},
+		},
+		{
+			"colored",
+			"[2024-05-12 14:04:11.402]  \x1b[1;44;37m miner   \x1b[0m \x1b[1;37mspeed\x1b[0m 10s/60s/15m \x1b[1;36m6512.3\x1b[0m \x1b[0;36m6498.7\x1b[0m \x1b[0;36mn/a\x1b[0m \x1b[1;36mH/s\x1b[0m max \x1b[1;36m6530.1 H/s\x1b[0m",
+			&hashrateInfo{tenSec: f(6512.3), sixtySec: f(6498.7), max: f(6530.1)},
+		},
+		{
+			"kilohashes",
+			"[2024-05-12 14:04:11.402]  miner    speed 10s/60s/15m 12.5 12.4 n/a kH/s max 12.9 kH/s",
+			&hashrateInfo{tenSec: f(12500), sixtySec: f(12400), max: f(12900)},
+		},
+		{
+			"no max",
+			"[2024-05-12 14:04:11.402]  miner    speed 10s/60s/15m 6512.3 6498.7 n/a H/s",
+			&hashrateInfo{tenSec: f(6512.3), sixtySec: f(6498.7)},
+		},
+		{
+			"max truncated",
+			"[2024-05-12 14:04:11.402]  miner    speed 10s/60s/15m 6512.3 6498.7 n/a H/s max 6530.1",
+			&hashrateInfo{tenSec: f(6512.3), sixtySec: f(6498.7)},
+		},
+		{"averages truncated", "[2024-05-12 14:04:11.402]  miner    speed 10s/60s/15m 6512.3 6498.7", nil},
+		{"unit missing", "[2024-05-12 14:04:11.402]  miner    speed 10s/60s/15m 6512.3 6498.7 n/a max 6530.1 H/s", nil},
+		{"garbled value", "[2024-05-12 14:04:11.402]  miner    speed 10s/60s/15m 65l2.3 6498.7 n/a H/s max 6530.1 H/s", nil},
+		{"share line", "[2024-05-12 14:03:45.120]  cpu      accepted (12/1) diff 120001 (52 ms)", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseHashrateFromLog(tt.line)
+			if ok != (tt.want != nil) {
+				t.Fatalf("parseHashrateFromLog() ok = %t, want %t", ok, tt.want != nil)
+			}
+			if ok && !reflect.DeepEqual(got, *tt.want) {
+				t.Fatalf("parseHashrateFromLog() = %s, want %s", formatHashrate(got), formatHashrate(*tt.want))
+			}
+		})
+	}
+}
+
+func formatHashrate(info hashrateInfo) string {
+	value := func(v *float64) string {
+		if v == nil {
+			return "n/a"
+		}
+		return strconv.FormatFloat(*v, 'f', -1, 64)
+	}
+	return value(info.tenSec) + " " + value(info.sixtySec) + " " + value(info.fifteenMin) + " max " + value(info.max)
+}
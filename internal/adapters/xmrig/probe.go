@@ -0,0 +1,166 @@
+package xmrig
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/restartfu/grid-node/internal/domain"
+	"github.com/restartfu/grid-node/internal/observability"
+)
+
+// probeState is the last probe result and when the pool last answered.
+type probeState struct {
+	last        *domain.XMRigPoolProbe
+	lastSuccess time.Time
+	lastError   string
+	lastErrorAt time.Time
+	failures    int
+}
+
+// probePools resolves and dials the active pool every interval, independent
+// of xmrig, so DNS or routing problems show up even while it is stopped.
+func (r *Wrapper) probePools(ctx context.Context) {
+	ticker := time.NewTicker(r.config.Probe.Interval)
+	defer ticker.Stop()
+	for {
+		pool := r.config.Miner.Pools[0]
+		if failoverEnabled(r.config.Miner) {
+			active, _ := r.state.activePool()
+			pool = r.config.Miner.Pools[active]
+		}
+		probeCtx, cancel := context.WithTimeout(ctx, r.config.Probe.Timeout)
+		result := probePool(probeCtx, pool)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		if r.state.recordProbe(result) {
+			err := fmt.Errorf("pool %s unreachable: %s: %s", result.Pool, result.Stage, result.Error)
+			log.Printf("xmrig pool probe: %v", err)
+			observability.CaptureError(err, map[string]string{
+				"component": "xmrig",
+				"operation": "pool_probe",
+			}, map[string]interface{}{
+				"pool":      result.Pool,
+				"stage":     result.Stage,
+				"addresses": result.Addresses,
+			})
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// probePool measures DNS resolution, the TCP connect and, for TLS pools, the
+// handshake. Stage names the step that failed.
+func probePool(ctx context.Context, pool PoolConfig) domain.XMRigPoolProbe {
+	result := domain.XMRigPoolProbe{
+		Time: time.Now().UTC(),
+		Pool: pool.URL,
+		TLS:  pool.TLS,
+	}
+	fail := func(stage string, err error) domain.XMRigPoolProbe {
+		result.Stage = stage
+		result.Error = err.Error()
+		return result
+	}
+
+	host, port, err := net.SplitHostPort(poolAddress(pool.URL))
+	if err != nil {
+		return fail(domain.XMRigProbeStageDNS, err)
+	}
+	started := time.Now()
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	dns := milliseconds(time.Since(started))
+	result.DNSMS = &dns
+	if err != nil {
+		return fail(domain.XMRigProbeStageDNS, err)
+	}
+	for _, addr := range addrs {
+		result.Addresses = append(result.Addresses, addr.String())
+	}
+	if len(addrs) == 0 {
+		return fail(domain.XMRigProbeStageDNS, errors.New("no addresses"))
+	}
+
+	dialer := net.Dialer{}
+	started = time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(addrs[0].String(), port))
+	if err != nil {
+		return fail(domain.XMRigProbeStageConnect, err)
+	}
+	defer conn.Close()
+	connect := milliseconds(time.Since(started))
+	result.ConnectMS = &connect
+
+	if pool.TLS {
+		client := tls.Client(conn, &tls.Config{ServerName: host})
+		started = time.Now()
+		if err := client.HandshakeContext(ctx); err != nil {
+			return fail(domain.XMRigProbeStageTLS, err)
+		}
+		handshake := milliseconds(time.Since(started))
+		result.TLSMS = &handshake
+	}
+	result.OK = true
+	return result
+}
+
+// poolAddress strips the stratum scheme xmrig accepts in pool URLs.
+func poolAddress(url string) string {
+	for _, scheme := range []string{"stratum+tcp://", "stratum+ssl://"} {
+		url = strings.TrimPrefix(url, scheme)
+	}
+	return url
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// recordProbe stores a result and returns true when the pool just went from
+// reachable, or unknown, to unreachable.
+func (s *state) recordProbe(result domain.XMRigPoolProbe) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.probe.last = &result
+	if result.OK {
+		s.probe.lastSuccess = result.Time
+		s.probe.failures = 0
+		return false
+	}
+	s.probe.lastError = result.Stage + ": " + result.Error
+	s.probe.lastErrorAt = result.Time
+	s.probe.failures++
+	return s.probe.failures == 1
+}
+
+func (s *state) probeSnapshot() *domain.XMRigPoolProbe {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.probe.last == nil {
+		return nil
+	}
+	probe := *s.probe.last
+	probe.Addresses = append([]string{}, probe.Addresses...)
+	probe.ConsecutiveFailures = s.probe.failures
+	probe.LastError = s.probe.lastError
+	if !s.probe.lastSuccess.IsZero() {
+		timestamp := s.probe.lastSuccess
+		probe.LastSuccessTime = &timestamp
+	}
+	if !s.probe.lastErrorAt.IsZero() {
+		timestamp := s.probe.lastErrorAt
+		probe.LastErrorTime = &timestamp
+	}
+	return &probe
+}
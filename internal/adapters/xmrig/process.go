@@ -0,0 +1,108 @@
+package xmrig
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// outputPipes connects xmrig's stdout and stderr to pipes owned by the
+// wrapper rather than by exec.Cmd, so Wait can return as soon as xmrig exits
+// even if a leftover child still holds the write ends.
+type outputPipes struct {
+	stdout       *os.File
+	stderr       *os.File
+	stdoutWriter *os.File
+	stderrWriter *os.File
+}
+
+func attachOutput(cmd *exec.Cmd) (*outputPipes, error) {
+	stdout, stdoutWriter, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, stderrWriter, err := os.Pipe()
+	if err != nil {
+		stdout.Close()
+		stdoutWriter.Close()
+		return nil, err
+	}
+	cmd.Stdout = stdoutWriter
+	cmd.Stderr = stderrWriter
+	return &outputPipes{
+		stdout:       stdout,
+		stderr:       stderr,
+		stdoutWriter: stdoutWriter,
+		stderrWriter: stderrWriter,
+	}, nil
+}
+
+// closeWriters drops the parent's copies of the write ends once the child
+// has inherited them, so readers see EOF when the child side goes away.
+func (p *outputPipes) closeWriters() {
+	p.stdoutWriter.Close()
+	p.stderrWriter.Close()
+}
+
+func (p *outputPipes) closeReaders() {
+	p.stdout.Close()
+	p.stderr.Close()
+}
+
+// newProcessGroup starts xmrig as the leader of its own process group so
+// signals reach anything it spawns.
+func newProcessGroup(path string, args []string) *exec.Cmd {
+	cmd := exec.Command(path, args...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	return cmd
+}
+
+// terminateOnCancel sends SIGTERM to the process group once ctx is cancelled
+// and SIGKILL when xmrig is still around after the grace period.
+func terminateOnCancel(ctx context.Context, cmd *exec.Cmd, grace time.Duration, exited <-chan struct{}) {
+	select {
+	case <-exited:
+		return
+	case <-ctx.Done():
+	}
+	pgid := cmd.Process.Pid
+	_ = syscall.Kill(-pgid, syscall.SIGTERM)
+	// A paused xmrig only handles SIGTERM once it is continued.
+	_ = syscall.Kill(-pgid, syscall.SIGCONT)
+
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+	select {
+	case <-exited:
+	case <-timer.C:
+		log.Printf("xmrig did not exit within %s, killing process group", grace)
+		_ = syscall.Kill(-pgid, syscall.SIGKILL)
+	}
+}
+
+// killProcessGroup removes whatever xmrig left behind in its group.
+func killProcessGroup(cmd *exec.Cmd) {
+	_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
+
+// exitDetails extracts the exit code and terminating signal from Wait's error.
+func exitDetails(err error) (int, string) {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return 0, ""
+	}
+	status, ok := exitErr.Sys().(syscall.WaitStatus)
+	if !ok {
+		return exitErr.ExitCode(), ""
+	}
+	if status.Signaled() {
+		return -1, unix.SignalName(status.Signal())
+	}
+	return status.ExitStatus(), ""
+}
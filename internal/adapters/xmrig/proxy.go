@@ -0,0 +1,85 @@
+package xmrig
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/restartfu/grid-node/internal/domain"
+	"github.com/restartfu/grid-node/internal/observability"
+	"github.com/restartfu/grid-node/internal/stratum"
+)
+
+func (r *Wrapper) serveProxy(ctx context.Context) {
+	if err := r.config.Proxy.Serve(ctx); err != nil {
+		log.Printf("stratum proxy: %v", err)
+		observability.CaptureError(err, map[string]string{
+			"component": "xmrig",
+			"operation": "stratum_proxy",
+		}, nil)
+	}
+}
+
+// routeThroughProxy points xmrig at the local stratum proxy and the proxy at
+// the pool xmrig would otherwise connect to.
+func (r *Wrapper) routeThroughProxy(miner MinerConfig) MinerConfig {
+	proxy := r.config.Proxy
+	if proxy == nil || len(miner.Pools) == 0 {
+		return miner
+	}
+	pool := miner.Pools[0]
+	proxy.SetUpstream(stratum.Upstream{Address: poolAddress(pool.URL), TLS: pool.TLS})
+	pool.URL = proxy.Addr()
+	pool.TLS = false
+	miner.Pools = []PoolConfig{pool}
+	return miner
+}
+
+// handleProxyError is called once the proxy gave up on the upstream, after
+// retrying for its whole MaxOutage budget. That is a failure in its own
+// right, so it switches pools without waiting for Threshold; reconnects the
+// proxy hides from xmrig never get here.
+func (r *Wrapper) handleProxyError(address string, err error) {
+	generation := r.state.recordProxyFailure(poolError{pool: address, message: err.Error()})
+	if !failoverEnabled(r.config.Miner) {
+		return
+	}
+	event, ok := r.state.recordFailoverDue(r.config.Miner.Pools, generation, 1, time.Now().UTC())
+	if !ok {
+		return
+	}
+	r.finishSwitch(event)
+}
+
+// recordProxyFailure counts a proxy upstream failure against the current
+// process and returns its generation.
+func (s *state) recordProxyFailure(failure poolError) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recordPoolFailureLocked(failure)
+	return s.failover.generation
+}
+
+func proxySnapshot(stats stratum.Stats) *domain.XMRigProxyStatus {
+	status := &domain.XMRigProxyStatus{
+		Address:           stats.Address,
+		Upstream:          stats.Upstream,
+		UpstreamConnected: stats.UpstreamConnected,
+		Miners:            stats.Miners,
+		Reconnects:        stats.Reconnects,
+		Jobs:              stats.Jobs,
+		SharesSubmitted:   stats.Submitted,
+		SharesAccepted:    stats.Accepted,
+		SharesRejected:    stats.Rejected,
+		LastError:         stats.LastError,
+	}
+	if !stats.LastJob.IsZero() {
+		timestamp := stats.LastJob
+		status.LastJobTime = &timestamp
+	}
+	if !stats.LastErrorAt.IsZero() {
+		timestamp := stats.LastErrorAt
+		status.LastErrorTime = &timestamp
+	}
+	return status
+}
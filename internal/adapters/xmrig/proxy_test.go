@@ -0,0 +1,173 @@
+package xmrig
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/restartfu/grid-node/internal/stratum"
+)
+
+// stratumPool answers every login with a job and can drop its connections.
+type stratumPool struct {
+	listener net.Listener
+
+	mu     sync.Mutex
+	conns  []net.Conn
+	logins int
+}
+
+func startStratumPool(t *testing.T) *stratumPool {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := &stratumPool{listener: listener}
+	t.Cleanup(func() {
+		listener.Close()
+		pool.drop()
+	})
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			pool.mu.Lock()
+			pool.conns = append(pool.conns, conn)
+			pool.mu.Unlock()
+			go pool.handle(conn)
+		}
+	}()
+	return pool
+}
+
+func (p *stratumPool) handle(conn net.Conn) {
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		var request struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &request) != nil || request.Method != "login" {
+			continue
+		}
+		p.mu.Lock()
+		p.logins++
+		id := fmt.Sprintf("sess-%d", p.logins)
+		p.mu.Unlock()
+		reply := fmt.Sprintf(`{"id":%s,"jsonrpc":"2.0","result":{"id":%q,"job":{"job_id":"login-%s","blob":"00","target":"ffff"},"status":"OK"}}`+"\n", request.ID, id, id)
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func (p *stratumPool) drop() {
+	p.mu.Lock()
+	conns := p.conns
+	p.conns = nil
+	p.mu.Unlock()
+	for _, conn := range conns {
+		conn.Close()
+	}
+}
+
+// startProxiedWrapper runs a wrapper whose first pool is pool behind a
+// stratum proxy, with a stand-in process so a failover stop is observable.
+func startProxiedWrapper(t *testing.T, pool string, maxOutage time.Duration) (*Wrapper, <-chan struct{}) {
+	t.Helper()
+	proxy, err := stratum.Listen(stratum.Config{MaxOutage: maxOutage, ReconnectDelay: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	miner := DefaultMinerConfig()
+	miner.Pools[0].URL = pool
+	miner.Pools[0].TLS = false
+	miner.Pools = append(miner.Pools, PoolConfig{URL: "fallback.example:443", User: "wallet", Coin: "monero", TLS: true})
+	r := NewWrapper(io.Discard, Config{Miner: miner, Failover: FailoverConfig{Threshold: 1}, Proxy: proxy})
+	r.routeThroughProxy(r.minerConfigForLaunch())
+	r.state.recordStart(time.Now().UTC(), 1)
+	stopped := make(chan struct{})
+	var once sync.Once
+	r.setProcess(&process{cancel: func() { once.Do(func() { close(stopped) }) }})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan struct{})
+	go func() {
+		defer close(served)
+		r.serveProxy(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-served
+	})
+	return r, stopped
+}
+
+// loginThroughProxy connects to the proxy the way xmrig does.
+func loginThroughProxy(t *testing.T, r *Wrapper) (net.Conn, *bufio.Scanner) {
+	t.Helper()
+	conn, err := net.Dial("tcp", r.config.Proxy.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	if _, err := io.WriteString(conn, `{"id":1,"jsonrpc":"2.0","method":"login","params":{"login":"wallet","pass":"x"}}`+"\n"); err != nil {
+		t.Fatal(err)
+	}
+	scanner := bufio.NewScanner(conn)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if !scanner.Scan() {
+		t.Fatalf("no login reply: %v", scanner.Err())
+	}
+	return conn, scanner
+}
+
+func TestProxyReconnectDoesNotRestartXMRig(t *testing.T) {
+	pool := startStratumPool(t)
+	r, stopped := startProxiedWrapper(t, pool.listener.Addr().String(), time.Minute)
+	conn, scanner := loginThroughProxy(t, r)
+
+	pool.drop()
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if !scanner.Scan() {
+		t.Fatalf("no job after the pool dropped: %v", scanner.Err())
+	}
+	select {
+	case <-stopped:
+		t.Fatal("xmrig was stopped for a drop the proxy recovered from")
+	default:
+	}
+	status := r.Status()
+	if status.Proxy.Reconnects != 1 {
+		t.Errorf("proxy reconnects = %d, want 1", status.Proxy.Reconnects)
+	}
+	if status.Failover.Switches != 0 || status.Failover.Failures != 0 {
+		t.Errorf("failover switches %d, failures %d; want 0, 0", status.Failover.Switches, status.Failover.Failures)
+	}
+}
+
+func TestProxyOutageSwitchesPool(t *testing.T) {
+	pool := startStratumPool(t)
+	r, stopped := startProxiedWrapper(t, pool.listener.Addr().String(), 100*time.Millisecond)
+	loginThroughProxy(t, r)
+
+	pool.listener.Close()
+	pool.drop()
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("xmrig was not stopped after the proxy gave up on the pool")
+	}
+	if status := r.Status().Failover; status.ActiveIndex != 1 || status.Switches != 1 {
+		t.Fatalf("active %d, switches %d; want 1, 1", status.ActiveIndex, status.Switches)
+	}
+}
@@ -0,0 +1,183 @@
+package xmrig
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/restartfu/grid-node/internal/domain"
+)
+
+var hugePagesRegex = regexp.MustCompile(`\bHUGE PAGES\s+(supported|unavailable|disabled|permission granted)`)
+
+var oneGBPagesRegex = regexp.MustCompile(`\b1GB PAGES\s+(supported|unavailable|disabled)`)
+
+var datasetAllocationRegex = regexp.MustCompile(`\ballocated (\d+) MB \([^)]*\) huge pages (\d+)% (\d+)/(\d+)`)
+
+var msrAppliedRegex = regexp.MustCompile(`\bregister values for "([^"]+)" preset ha(?:ve|s) been set successfully`)
+
+var msrErrorRegex = regexp.MustCompile(`(?i)\bmsr\s+(.*\b(?:cannot|failed|privileges required)\b.*?)\s*$`)
+
+var datasetInitRegex = regexp.MustCompile(`\binit dataset algo (\S+)`)
+
+var datasetReadyRegex = regexp.MustCompile(`\bdataset ready \((\d+) ms\)`)
+
+// randomxLine is one RandomX readiness fact from xmrig's startup output.
+type randomxLine struct {
+	hugePages  string
+	oneGBPages string
+	allocation *datasetAllocation
+	msrPreset  string
+	msrError   string
+	initAlgo   string
+	readyMS    *int64
+}
+
+// datasetAllocation is the "randomx allocated" line: how many of the pages
+// backing the dataset and cache are huge pages.
+type datasetAllocation struct {
+	megabytes int
+	percent   float64
+	huge      int
+	total     int
+}
+
+// randomxState accumulates the lines of the current xmrig process.
+type randomxState struct {
+	hugePages    string
+	oneGBPages   string
+	allocation   *datasetAllocation
+	msr          string
+	msrPreset    string
+	msrError     string
+	algo         string
+	initializing bool
+	readyMS      *int64
+	readyAt      time.Time
+}
+
+// parseRandomXLine recognises the HUGE PAGES and 1GB PAGES banner, the dataset
+// allocation, MSR preset results and dataset initialisation.
+func parseRandomXLine(line string) (randomxLine, bool) {
+	var info randomxLine
+	found := false
+	if match := hugePagesRegex.FindStringSubmatch(line); match != nil {
+		info.hugePages, found = match[1], true
+	}
+	if match := oneGBPagesRegex.FindStringSubmatch(line); match != nil {
+		info.oneGBPages, found = match[1], true
+	}
+	if match := datasetAllocationRegex.FindStringSubmatch(line); match != nil {
+		allocation := datasetAllocation{}
+		allocation.megabytes, _ = strconv.Atoi(match[1])
+		allocation.percent, _ = strconv.ParseFloat(match[2], 64)
+		allocation.huge, _ = strconv.Atoi(match[3])
+		allocation.total, _ = strconv.Atoi(match[4])
+		info.allocation, found = &allocation, true
+	}
+	if match := msrAppliedRegex.FindStringSubmatch(line); match != nil {
+		info.msrPreset, found = match[1], true
+	} else if match := msrErrorRegex.FindStringSubmatch(line); match != nil {
+		info.msrError, found = match[1], true
+	}
+	if match := datasetInitRegex.FindStringSubmatch(line); match != nil {
+		info.initAlgo, found = match[1], true
+	}
+	if match := datasetReadyRegex.FindStringSubmatch(line); match != nil {
+		ms, _ := strconv.ParseInt(match[1], 10, 64)
+		info.readyMS, found = &ms, true
+	}
+	return info, found
+}
+
+func (s *state) recordRandomXLocked(info randomxLine, at time.Time) {
+	rx := &s.randomx
+	if info.hugePages != "" {
+		rx.hugePages = info.hugePages
+	}
+	if info.oneGBPages != "" {
+		rx.oneGBPages = info.oneGBPages
+	}
+	if info.allocation != nil {
+		rx.allocation = info.allocation
+	}
+	if info.msrPreset != "" {
+		rx.msr = domain.XMRigMSROK
+		rx.msrPreset = info.msrPreset
+		rx.msrError = ""
+	}
+	// xmrig logs the failed register first and the summary after it; keep
+	// the first line as it names the cause.
+	if info.msrError != "" && rx.msr != domain.XMRigMSRFailed {
+		rx.msr = domain.XMRigMSRFailed
+		rx.msrError = info.msrError
+	}
+	if info.initAlgo != "" {
+		rx.algo = info.initAlgo
+		rx.initializing = true
+	}
+	if info.readyMS != nil {
+		rx.initializing = false
+		rx.readyMS = info.readyMS
+		rx.readyAt = at
+	}
+}
+
+// randomxSnapshot reports the current process's RandomX readiness;
+// oneGBPages is whether the rendered config asks for 1GB pages.
+func (s *state) randomxSnapshot(oneGBPages bool) *domain.XMRigRandomX {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rx := s.randomx
+	if rx.hugePages == "" && rx.oneGBPages == "" && rx.allocation == nil && rx.msr == "" && rx.algo == "" && rx.readyMS == nil {
+		return nil
+	}
+	status := &domain.XMRigRandomX{
+		Mode:          s.randomxMode,
+		HugePages:     rx.hugePages,
+		OneGBPages:    rx.oneGBPages,
+		MSR:           rx.msr,
+		MSRPreset:     rx.msrPreset,
+		MSRError:      rx.msrError,
+		Algo:          rx.algo,
+		Initializing:  rx.initializing,
+		DatasetReady:  !rx.initializing && rx.readyMS != nil,
+		DatasetInitMS: copyInt64(rx.readyMS),
+	}
+	if rx.allocation != nil {
+		percent := rx.allocation.percent
+		status.HugePagesPercent = &percent
+		status.HugePagesAllocated = rx.allocation.huge
+		status.HugePagesTotal = rx.allocation.total
+		status.DatasetMB = rx.allocation.megabytes
+	}
+	if !rx.readyAt.IsZero() {
+		timestamp := rx.readyAt
+		status.DatasetReadyTime = &timestamp
+	}
+	switch rx.hugePages {
+	case "unavailable":
+		status.DegradedReasons = append(status.DegradedReasons, "huge pages are unavailable")
+	case "disabled":
+		status.DegradedReasons = append(status.DegradedReasons, "huge pages are disabled")
+	}
+	if oneGBPages && rx.oneGBPages == "unavailable" {
+		status.DegradedReasons = append(status.DegradedReasons, "1GB pages are enabled but unavailable")
+	}
+	if rx.allocation != nil && rx.allocation.percent < 100 {
+		status.DegradedReasons = append(status.DegradedReasons, "only "+strconv.FormatFloat(rx.allocation.percent, 'f', -1, 64)+"% of the dataset uses huge pages")
+	}
+	if rx.msr == domain.XMRigMSRFailed {
+		status.DegradedReasons = append(status.DegradedReasons, "MSR mod failed: "+rx.msrError)
+	}
+	status.Degraded = len(status.DegradedReasons) > 0
+	return status
+}
+
+func copyInt64(value *int64) *int64 {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
@@ -0,0 +1,82 @@
+package xmrig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRandomXLine(t *testing.T) {
+	ms := func(v int64) *int64 { return &v }
+	tests := []struct {
+		name string
+		line string
+		want *randomxLine
+	}{
+		{
+			"huge pages",
+			" * HUGE PAGES     supported",
+			&randomxLine{hugePages: "supported"},
+		},
+		{
+			"huge pages colored",
+			" \x1b[1;32m * \x1b[0m\x1b[1;37mHUGE PAGES   \x1b[0m\x1b[1;32mpermission granted\x1b[0m",
+			&randomxLine{hugePages: "permission granted"},
+		},
+		{
+			"1gb pages",
+			" * 1GB PAGES      unavailable",
+			&randomxLine{oneGBPages: "unavailable"},
+		},
+		{
+			"dataset init",
+			"[2024-05-12 14:03:11.501]  randomx  init dataset algo rx/0 (8 threads) seed 1c2b3d4e5f...",
+			&randomxLine{initAlgo: "rx/0"},
+		},
+		{
+			"allocation",
+			"[2024-05-12 14:03:11.502]  randomx  allocated 2336 MB (2080+256) huge pages 100% 1168/1168 +JIT (1042 ms)",
+			&randomxLine{allocation: &datasetAllocation{megabytes: 2336, percent: 100, huge: 1168, total: 1168}},
+		},
+		{
+			"partial allocation",
+			"[2024-05-12 14:03:11.502]  randomx  allocated 2336 MB (2080+256) huge pages 38% 448/1168 +JIT (880 ms)",
+			&randomxLine{allocation: &datasetAllocation{megabytes: 2336, percent: 38, huge: 448, total: 1168}},
+		},
+		{
+			"dataset ready",
+			"[2024-05-12 14:03:17.332]  randomx  dataset ready (5830 ms)",
+			&randomxLine{readyMS: ms(5830)},
+		},
+		{
+			"msr preset",
+			`[2024-05-12 14:03:11.402]  msr      register values for "ryzen_19h" preset have been set successfully (12 ms)`,
+			&randomxLine{msrPreset: "ryzen_19h"},
+		},
+		{
+			"msr cannot read",
+			"[2024-05-12 14:03:11.402]  msr      cannot read MSR 0x000001a4",
+			&randomxLine{msrError: "cannot read MSR 0x000001a4"},
+		},
+		{
+			"msr summary",
+			"[2024-05-12 14:03:11.402]  msr      FAILED TO APPLY MSR MOD, HASHRATE WILL BE LOW",
+			&randomxLine{msrError: "FAILED TO APPLY MSR MOD, HASHRATE WILL BE LOW"},
+		},
+		{
+			"msr privileges",
+			"[2024-05-12 14:03:11.402]  msr      to access MSR registers Administrator privileges required.",
+			&randomxLine{msrError: "to access MSR registers Administrator privileges required."},
+		},
+		{"allocation truncated", "[2024-05-12 14:03:11.502]  randomx  allocated 2336 MB (2080+256) huge pages 100%", nil},
+		{"dataset ready truncated", "[2024-05-12 14:03:17.332]  randomx  dataset ready (5830", nil},
+		{"hashrate line", "[2024-05-12 14:04:11.402]  miner    speed 10s/60s/15m n/a n/a n/a H/s max n/a H/s", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseLine(tt.line).randomx
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("randomx = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package xmrig
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/restartfu/grid-node/internal/domain"
+	"github.com/restartfu/grid-node/internal/observability"
+	"github.com/restartfu/grid-node/internal/specs"
+)
+
+type resourceState struct {
+	current *domain.XMRigResources
+	history []domain.XMRigResources
+	// cpuSeconds is the previous sample's user plus system time, used for
+	// CPUPercent.
+	cpuSeconds float64
+}
+
+// sampleResources reads /proc for the xmrig process every interval until ctx
+// is cancelled.
+func (r *Wrapper) sampleResources(ctx context.Context, pid int) {
+	ticker := time.NewTicker(r.config.ResourceInterval)
+	defer ticker.Stop()
+	reported := false
+	for {
+		sample, err := readProcResources(pid)
+		switch {
+		case err == nil:
+			r.state.recordResources(sample, r.config.ResourceHistorySize)
+		case ctx.Err() == nil && !errors.Is(err, fs.ErrNotExist) && !reported:
+			// Report once per process; the next reads fail the same way.
+			reported = true
+			log.Printf("xmrig resources: %v", err)
+			observability.CaptureError(err, map[string]string{
+				"component": "xmrig",
+				"operation": "resource_sample",
+			}, map[string]interface{}{
+				"pid": pid,
+			})
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func readProcResources(pid int) (domain.XMRigResources, error) {
+	dir := "/proc/" + strconv.Itoa(pid)
+	sample := domain.XMRigResources{Time: time.Now().UTC(), PID: pid}
+
+	stat, err := specs.ReadProcessStat(pid)
+	if err != nil {
+		return sample, err
+	}
+	ticks := float64(specs.ClockTicks())
+	sample.UserSeconds = float64(stat.UserTicks) / ticks
+	sample.SystemSeconds = float64(stat.SystemTicks) / ticks
+	sample.Nice = stat.Nice
+	sample.Threads = stat.Threads
+
+	status, err := readProcKeyValues(dir + "/status")
+	if err != nil {
+		return sample, err
+	}
+	sample.RSSBytes = status["VmRSS"] * 1024
+	sample.PeakRSSBytes = status["VmHWM"] * 1024
+	sample.VoluntaryCtxSwitches = status["voluntary_ctxt_switches"]
+	sample.InvoluntaryCtxSwitches = status["nonvoluntary_ctxt_switches"]
+
+	// io needs ptrace access to the process; without it the counters are
+	// left out rather than failing the sample.
+	if io, err := readProcKeyValues(dir + "/io"); err == nil {
+		read, write := io["read_bytes"], io["write_bytes"]
+		sample.ReadBytes = &read
+		sample.WriteBytes = &write
+	}
+	return sample, nil
+}
+
+// readProcKeyValues parses "Key: value [kB]" lines, skipping values that are
+// not numbers.
+func readProcKeyValues(path string) (map[string]uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	values := make(map[string]uint64)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, rest, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		if value, err := strconv.ParseUint(fields[0], 10, 64); err == nil {
+			values[key] = value
+		}
+	}
+	return values, scanner.Err()
+}
+
+func (s *state) recordResources(sample domain.XMRigResources, historySize int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cpuSeconds := sample.UserSeconds + sample.SystemSeconds
+	if previous := s.resources.current; previous != nil && previous.PID == sample.PID {
+		if elapsed := sample.Time.Sub(previous.Time).Seconds(); elapsed > 0 {
+			percent := (cpuSeconds - s.resources.cpuSeconds) / elapsed * 100
+			sample.CPUPercent = &percent
+		}
+	}
+	s.resources.cpuSeconds = cpuSeconds
+	s.resources.current = &sample
+	s.resources.history = append(s.resources.history, sample)
+	if len(s.resources.history) > historySize {
+		s.resources.history = s.resources.history[len(s.resources.history)-historySize:]
+	}
+}
+
+func (s *state) resourcesSnapshot() *domain.XMRigResources {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.resources.current == nil {
+		return nil
+	}
+	sample := *s.resources.current
+	return &sample
+}
+
+// ResourceHistory returns recorded /proc samples, oldest first, across xmrig
+// restarts.
+func (r *Wrapper) ResourceHistory(query domain.XMRigResourceQuery) domain.XMRigResourceHistory {
+	return domain.XMRigResourceHistory{
+		Interval: r.config.ResourceInterval,
+		Samples:  r.state.resourceHistory(query),
+	}
+}
+
+func (s *state) resourceHistory(query domain.XMRigResourceQuery) []domain.XMRigResources {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	samples := make([]domain.XMRigResources, 0, len(s.resources.history))
+	for _, sample := range s.resources.history {
+		if !query.Since.IsZero() && !sample.Time.After(query.Since) {
+			continue
+		}
+		samples = append(samples, sample)
+	}
+	if query.Limit > 0 && len(samples) > query.Limit {
+		samples = samples[len(samples)-query.Limit:]
+	}
+	return samples
+}
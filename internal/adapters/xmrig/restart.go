@@ -0,0 +1,73 @@
+package xmrig
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// restartJitter spreads restart delays by up to ±20% so a fleet of nodes
+// failing together does not retry in lockstep.
+const restartJitter = 0.2
+
+// restartPolicy decides how long to wait before relaunching xmrig after an
+// unexpected exit and detects crash loops.
+type restartPolicy struct {
+	mu     sync.Mutex
+	base   time.Duration
+	max    time.Duration
+	limit  int
+	window time.Duration
+	next   time.Duration
+	exits  []time.Time
+}
+
+func newRestartPolicy(cfg Config) *restartPolicy {
+	return &restartPolicy{
+		base:   cfg.RestartDelay,
+		max:    cfg.MaxRestartDelay,
+		limit:  cfg.CrashLoopExits,
+		window: cfg.CrashLoopWindow,
+		next:   cfg.RestartDelay,
+	}
+}
+
+// recordExit registers an exit at the given time and returns the delay to
+// wait before the next launch and whether xmrig is now crash looping.
+func (p *restartPolicy) recordExit(at time.Time) (time.Duration, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.exits = append(p.exits, at)
+	cutoff := at.Add(-p.window)
+	kept := p.exits[:0]
+	for _, exit := range p.exits {
+		if exit.After(cutoff) {
+			kept = append(kept, exit)
+		}
+	}
+	p.exits = kept
+
+	delay := jitter(p.next)
+	if delay > p.max {
+		delay = p.max
+	}
+	p.next *= 2
+	if p.next > p.max {
+		p.next = p.max
+	}
+	return delay, len(p.exits) >= p.limit
+}
+
+// reset forgets previous exits once xmrig has been up long enough.
+func (p *restartPolicy) reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.next = p.base
+	p.exits = nil
+}
+
+func jitter(d time.Duration) time.Duration {
+	factor := 1 - restartJitter + rand.Float64()*2*restartJitter
+	return time.Duration(float64(d) * factor)
+}
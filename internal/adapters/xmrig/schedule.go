@@ -0,0 +1,134 @@
+package xmrig
+
+import (
+	"context"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/restartfu/grid-node/internal/domain"
+	"github.com/restartfu/grid-node/internal/schedule"
+)
+
+// followSchedule applies the schedule at every transition until ctx is done.
+// Operator actions in between are left alone until the next boundary.
+func (r *Wrapper) followSchedule(ctx context.Context) {
+	for {
+		at, state, ok := r.config.Schedule.Next(time.Now())
+		if !ok {
+			return
+		}
+		timer := time.NewTimer(time.Until(at))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		r.applySchedule(state, at)
+	}
+}
+
+// applySchedule starts, stops or relaunches xmrig with the state's args.
+func (r *Wrapper) applySchedule(state schedule.State, at time.Time) {
+	name := "outside"
+	if state.Window != nil {
+		name = state.Window.Name
+	}
+	log.Printf("xmrig schedule: %s, mine=%t", name, state.Mine)
+	r.state.recordScheduleTransition(at.UTC())
+
+	if !state.Mine {
+		if r.state.desiredState() != domain.XMRigDesiredStopped {
+			r.state.setDesired(domain.XMRigDesiredStopped)
+			r.stopProcess(domain.XMRigStopSchedule)
+		}
+		r.notify()
+		return
+	}
+	changed := r.setScheduleArgs(state.Args)
+	if r.state.desiredState() != domain.XMRigDesiredRunning {
+		r.state.setDesired(domain.XMRigDesiredRunning)
+		// Leaves xmrig paused while a thermal or idle hold is set.
+		r.applyHolds()
+	}
+	if changed {
+		r.stopProcess(domain.XMRigStopSchedule)
+	}
+	r.notify()
+}
+
+// setScheduleArgs stores the active profile's args and reports a change.
+func (r *Wrapper) setScheduleArgs(args []string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if slices.Equal(r.scheduleArgs, args) {
+		return false
+	}
+	r.scheduleArgs = append([]string(nil), args...)
+	return true
+}
+
+func (r *Wrapper) scheduleLaunchArgs() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.scheduleArgs...)
+}
+
+// Schedule reports the active window and the next transition.
+func (r *Wrapper) Schedule() domain.XMRigSchedule {
+	sched := r.config.Schedule
+	if sched == nil {
+		return domain.XMRigSchedule{Windows: []domain.XMRigScheduleWindow{}}
+	}
+	now := time.Now()
+	current := sched.At(now)
+	response := domain.XMRigSchedule{
+		Enabled:        true,
+		Timezone:       sched.Location().String(),
+		Mining:         current.Mine,
+		Args:           append([]string{}, current.Args...),
+		LastTransition: r.state.scheduleTransition(),
+		Windows:        []domain.XMRigScheduleWindow{},
+	}
+	if current.Window != nil {
+		since, until := current.Since.UTC(), current.Until.UTC()
+		response.ActiveWindow = current.Window.Name
+		response.ActiveSince = &since
+		response.ActiveUntil = &until
+	}
+	if at, next, ok := sched.Next(now); ok {
+		at = at.UTC()
+		response.NextTransition = &at
+		response.NextMining = next.Mine
+		if next.Window != nil {
+			response.NextWindow = next.Window.Name
+		}
+	}
+	for _, window := range sched.Windows() {
+		response.Windows = append(response.Windows, domain.XMRigScheduleWindow{
+			Name:            window.Name,
+			Spec:            window.Spec,
+			DurationSeconds: int64(window.Duration / time.Second),
+			Mine:            window.Mine,
+			Args:            append([]string{}, window.Args...),
+		})
+	}
+	return response
+}
+
+func (s *state) recordScheduleTransition(at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scheduleAt = at
+}
+
+func (s *state) scheduleTransition() *time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.scheduleAt.IsZero() {
+		return nil
+	}
+	timestamp := s.scheduleAt
+	return &timestamp
+}
@@ -0,0 +1,189 @@
+package xmrig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/restartfu/grid-node/internal/domain"
+	"github.com/restartfu/grid-node/internal/observability"
+)
+
+var errThermalSensor = errors.New("cpu temperature unavailable")
+
+// thermalState is the guard's view kept alongside the rest of the status.
+type thermalState struct {
+	temp          *float64
+	tempAt        time.Time
+	sensorFailed  bool
+	tripped       bool
+	trippedAt     time.Time
+	interventions int
+	events        []domain.XMRigThermalEvent
+}
+
+// guardThermal samples the CPU temperature every interval and pauses or
+// throttles xmrig between the trip and resume thresholds.
+func (r *Wrapper) guardThermal(ctx context.Context) {
+	ticker := time.NewTicker(r.config.Thermal.Interval)
+	defer ticker.Stop()
+	for {
+		r.checkThermal(time.Now().UTC())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Wrapper) checkThermal(now time.Time) {
+	cfg := r.config.Thermal
+	temp, ok := r.readTemp()
+	if !ok {
+		if r.state.recordSensorFailure() {
+			log.Printf("xmrig thermal guard: %v", errThermalSensor)
+			observability.CaptureError(errThermalSensor, map[string]string{
+				"component": "xmrig",
+				"operation": "thermal_sensor",
+			}, nil)
+		}
+		return
+	}
+	tripped, since := r.state.recordTemperature(temp, now)
+	switch {
+	case !tripped && temp >= cfg.TripC:
+		r.tripThermal(temp, now)
+	case tripped && temp <= cfg.ResumeC && now.Sub(since) >= cfg.MinHold:
+		r.releaseThermal(temp, now)
+	case tripped && cfg.Action == domain.XMRigThermalPause:
+		// Keep operator-resumed processes paused.
+		r.applyHolds()
+	}
+}
+
+func (r *Wrapper) tripThermal(temp float64, at time.Time) {
+	cfg := r.config.Thermal
+	event := domain.XMRigThermalEvent{Time: at, Action: cfg.Action, TempC: temp}
+	switch cfg.Action {
+	case domain.XMRigThermalThrottle:
+		event.Threads = cfg.ThrottleThreads
+		r.setThreadLimit(cfg.ThrottleThreads)
+		r.interruptBenchmark("thermal throttle")
+		r.stopProcess(domain.XMRigStopThermal)
+	default:
+		r.setHold(holdThermal, true)
+	}
+	r.state.recordThermal(true, event)
+	r.reportThermal(fmt.Errorf("cpu temperature %.1f C reached trip point %.1f C", temp, cfg.TripC), event)
+}
+
+func (r *Wrapper) releaseThermal(temp float64, at time.Time) {
+	cfg := r.config.Thermal
+	event := domain.XMRigThermalEvent{Time: at, Action: domain.XMRigThermalResume, TempC: temp}
+	switch cfg.Action {
+	case domain.XMRigThermalThrottle:
+		r.setThreadLimit(0)
+		r.stopProcess(domain.XMRigStopThermal)
+	default:
+		r.setHold(holdThermal, false)
+	}
+	r.state.recordThermal(false, event)
+	r.reportThermal(fmt.Errorf("cpu temperature %.1f C back under resume point %.1f C", temp, cfg.ResumeC), event)
+}
+
+func (r *Wrapper) reportThermal(err error, event domain.XMRigThermalEvent) {
+	cfg := r.config.Thermal
+	log.Printf("xmrig thermal guard: %v, %s", err, event.Action)
+	observability.CaptureError(err, map[string]string{
+		"component": "xmrig",
+		"operation": "thermal_" + event.Action,
+	}, map[string]interface{}{
+		"temp_c":   event.TempC,
+		"trip_c":   cfg.TripC,
+		"resume_c": cfg.ResumeC,
+		"threads":  event.Threads,
+	})
+}
+
+func (r *Wrapper) setThreadLimit(threads int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.threadLimit = threads
+}
+
+// currentThreadLimit is xmrig's thread cap while the guard is throttling, or
+// zero.
+func (r *Wrapper) currentThreadLimit() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.threadLimit
+}
+
+// recordSensorFailure returns true on the first failed reading in a row.
+func (s *state) recordSensorFailure() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.thermal.temp = nil
+	first := !s.thermal.sensorFailed
+	s.thermal.sensorFailed = true
+	return first
+}
+
+func (s *state) recordTemperature(temp float64, at time.Time) (bool, time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.thermal.temp = &temp
+	s.thermal.tempAt = at
+	s.thermal.sensorFailed = false
+	return s.thermal.tripped, s.thermal.trippedAt
+}
+
+func (s *state) recordThermal(tripped bool, event domain.XMRigThermalEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.thermal.tripped = tripped
+	if tripped {
+		s.thermal.trippedAt = event.Time
+	}
+	s.thermal.interventions++
+	s.thermal.events = append(s.thermal.events, event)
+	if len(s.thermal.events) > maxThermalEvents {
+		s.thermal.events = s.thermal.events[len(s.thermal.events)-maxThermalEvents:]
+	}
+}
+
+func (s *state) isPaused() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.paused
+}
+
+func (s *state) thermalSnapshot(cfg ThermalConfig) *domain.XMRigThermalStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	status := &domain.XMRigThermalStatus{
+		Action:         cfg.Action,
+		TripC:          cfg.TripC,
+		ResumeC:        cfg.ResumeC,
+		MinHoldSeconds: int64(cfg.MinHold / time.Second),
+		Tripped:        s.thermal.tripped,
+		Interventions:  s.thermal.interventions,
+		Events:         append([]domain.XMRigThermalEvent{}, s.thermal.events...),
+		TempC:          copyFloat(s.thermal.temp),
+	}
+	if cfg.Action == domain.XMRigThermalThrottle {
+		status.ThrottleThreads = cfg.ThrottleThreads
+	}
+	if s.thermal.temp != nil {
+		timestamp := s.thermal.tempAt
+		status.TempTime = &timestamp
+	}
+	if s.thermal.tripped {
+		timestamp := s.thermal.trippedAt
+		status.TrippedAt = &timestamp
+	}
+	return status
+}
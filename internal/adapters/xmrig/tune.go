@@ -0,0 +1,562 @@
+package xmrig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/restartfu/grid-node/internal/domain"
+	"github.com/restartfu/grid-node/internal/observability"
+	"github.com/restartfu/grid-node/internal/specs"
+)
+
+// randomxL3PerThread is the L3 cache a RandomX thread wants for its scratchpad.
+const randomxL3PerThread = 2 << 20
+
+var defaultTuneModes = []string{"fast", "light"}
+
+// stopTuneTimeout is the internal stop reason for a candidate still running
+// when the sweep reaches TuneTimeout.
+const stopTuneTimeout = "tune_timeout"
+
+// tuneHoldPoll is how often a sweep waiting out a hold checks for its release.
+const tuneHoldPoll = time.Second
+
+// tuneJob is a queued or running auto-tune sweep.
+type tuneJob struct {
+	mu       sync.Mutex
+	result   domain.XMRigTune
+	req      domain.XMRigTuneRequest
+	topology specs.Topology
+}
+
+func (j *tuneJob) update(fn func(*domain.XMRigTune)) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	fn(&j.result)
+}
+
+func (j *tuneJob) snapshot() domain.XMRigTune {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	result := j.result
+	result.MaxTempC = copyFloat(result.MaxTempC)
+	result.EndTime = copyTime(result.EndTime)
+	result.Candidates = make([]domain.XMRigTuneCandidate, 0, len(j.result.Candidates))
+	for _, candidate := range j.result.Candidates {
+		result.Candidates = append(result.Candidates, copyTuneCandidate(candidate))
+	}
+	if result.Profile != nil {
+		profile := copyTuneProfile(*result.Profile)
+		result.Profile = &profile
+	}
+	return result
+}
+
+// tuneCandidate is one setting to benchmark.
+type tuneCandidate struct {
+	name      string
+	overrides domain.XMRigMinerOverrides
+}
+
+// Tune queues an auto-tune sweep. Regular mining stops until the winning
+// profile is applied.
+func (r *Wrapper) Tune(req domain.XMRigTuneRequest) (domain.XMRigTune, error) {
+	job, err := r.queueTune(req)
+	if err != nil {
+		return domain.XMRigTune{}, err
+	}
+	return job.snapshot(), nil
+}
+
+// Tuning returns the running or last auto-tune job and the applied profile.
+func (r *Wrapper) Tuning() domain.XMRigTuneStatus {
+	r.mu.Lock()
+	job := r.tune
+	if job == nil {
+		job = r.lastTune
+	}
+	r.mu.Unlock()
+	status := domain.XMRigTuneStatus{Profile: r.tuneProfileSnapshot()}
+	if job != nil {
+		result := job.snapshot()
+		status.Job = &result
+	}
+	return status
+}
+
+func (r *Wrapper) queueTune(req domain.XMRigTuneRequest) (*tuneJob, error) {
+	if req.Objective == "" {
+		req.Objective = domain.XMRigTuneHashrate
+	}
+	if req.Objective != domain.XMRigTuneHashrate && req.Objective != domain.XMRigTuneHashratePerWatt {
+		return nil, fmt.Errorf("%w: objective must be %s or %s", domain.ErrXMRigInvalidTune, domain.XMRigTuneHashrate, domain.XMRigTuneHashratePerWatt)
+	}
+	if req.Size == "" {
+		req.Size = domain.XMRigBenchmark1M
+	}
+	if !validBenchmarkSize(req.Size) {
+		return nil, domain.ErrXMRigInvalidBenchmarkSize
+	}
+	if req.MaxTempC == nil && r.config.Thermal.enabled() {
+		trip := r.config.Thermal.TripC
+		req.MaxTempC = &trip
+	}
+	if req.MaxTempC != nil && *req.MaxTempC <= 0 {
+		return nil, fmt.Errorf("%w: max_temp_c must be positive", domain.ErrXMRigInvalidTune)
+	}
+	topology := r.readTopology()
+	cpus := len(topology.CPUs)
+	for _, threads := range req.Threads {
+		if threads < 1 || threads > cpus {
+			return nil, fmt.Errorf("%w: thread count %d is outside 1 to %d", domain.ErrXMRigInvalidTune, threads, cpus)
+		}
+	}
+	if len(req.Threads) == 0 {
+		req.Threads = tuneThreadCounts(topology)
+	}
+	for _, mode := range req.RandomXModes {
+		if !contains(randomXModes, mode) {
+			return nil, fmt.Errorf("%w: unknown randomx mode %q", domain.ErrXMRigInvalidTune, mode)
+		}
+	}
+	if len(req.RandomXModes) == 0 {
+		req.RandomXModes = defaultTuneModes
+	}
+	id, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	job := &tuneJob{
+		result: domain.XMRigTune{
+			ID:        id[:12],
+			Status:    domain.XMRigTunePending,
+			Objective: req.Objective,
+			Size:      req.Size,
+			MaxTempC:  copyFloat(req.MaxTempC),
+			StartTime: time.Now().UTC(),
+			Topology: domain.XMRigCPUTopology{
+				CPUs:     cpus,
+				Cores:    topology.Cores(),
+				Packages: topology.Packages(),
+				L3Bytes:  topology.L3Bytes,
+			},
+			Candidates: []domain.XMRigTuneCandidate{},
+		},
+		req:      req,
+		topology: topology,
+	}
+
+	r.mu.Lock()
+	if r.tune != nil {
+		r.mu.Unlock()
+		return nil, domain.ErrXMRigTuneRunning
+	}
+	if r.benchmark != nil {
+		r.mu.Unlock()
+		return nil, domain.ErrXMRigBenchmarkRunning
+	}
+	r.tune = job
+	r.mu.Unlock()
+	r.stopProcess(domain.XMRigStopTune)
+	r.notify()
+	return job, nil
+}
+
+func (r *Wrapper) pendingTune() *tuneJob {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.tune
+}
+
+// runTune executes a queued sweep in place of regular mining and applies the
+// winner.
+func (r *Wrapper) runTune(ctx context.Context, job *tuneJob) {
+	job.update(func(result *domain.XMRigTune) {
+		result.Status = domain.XMRigTuneRunning
+	})
+	profile, err := r.executeTune(ctx, job)
+	now := time.Now().UTC()
+	job.update(func(result *domain.XMRigTune) {
+		result.EndTime = &now
+		result.Stage = ""
+		result.Status = domain.XMRigTuneDone
+		result.Profile = profile
+		if err != nil {
+			result.Status = domain.XMRigTuneFailed
+			result.Error = err.Error()
+		}
+	})
+	result := job.snapshot()
+	if err == nil {
+		log.Printf("xmrig auto-tune %s: threads %d, randomx mode %s, huge pages %t, %.1f H/s", result.ID, profile.Threads, profile.RandomXMode, profile.HugePages, profile.HashrateHS)
+	} else {
+		log.Printf("xmrig auto-tune %s: %v", result.ID, err)
+	}
+	if err != nil && !errors.Is(err, errBenchmarkStopped) {
+		observability.CaptureError(err, map[string]string{
+			"component": "xmrig",
+			"operation": "tune",
+		}, map[string]interface{}{
+			"id":         result.ID,
+			"objective":  result.Objective,
+			"candidates": len(result.Candidates),
+		})
+	}
+
+	r.mu.Lock()
+	r.tune = nil
+	r.lastTune = job
+	r.mu.Unlock()
+}
+
+// executeTune benchmarks every thread count with each affinity layout, then
+// the RandomX modes and huge page options on top of the best so far.
+// Settings that render to an already measured config are skipped. A
+// candidate stopped by a thermal or idle hold is recorded as skipped and the
+// sweep waits for the release before the next one. The whole sweep fails
+// once it runs past TuneTimeout.
+func (r *Wrapper) executeTune(ctx context.Context, job *tuneJob) (*domain.XMRigTuneProfile, error) {
+	deadline := time.Now().Add(r.config.TuneTimeout)
+	errTimeout := fmt.Errorf("sweep did not finish within %s", r.config.TuneTimeout)
+	stages := []struct {
+		name       string
+		candidates func(best domain.XMRigMinerOverrides) []tuneCandidate
+	}{
+		{domain.XMRigTuneStageThreads, func(domain.XMRigMinerOverrides) []tuneCandidate {
+			return threadCandidates(job.topology, job.req.Threads)
+		}},
+		{domain.XMRigTuneStageMode, func(best domain.XMRigMinerOverrides) []tuneCandidate {
+			return modeCandidates(best, job.req.RandomXModes)
+		}},
+		{domain.XMRigTuneStageHugePages, hugePageCandidates},
+	}
+	var best *domain.XMRigTuneCandidate
+	var bestOverrides domain.XMRigMinerOverrides
+	measured := make(map[string]bool)
+	for _, stage := range stages {
+		job.update(func(result *domain.XMRigTune) {
+			result.Stage = stage.name
+		})
+		for _, candidate := range stage.candidates(bestOverrides) {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("%w by %s", errBenchmarkStopped, domain.XMRigStopShutdown)
+			}
+			overrides := candidate.overrides
+			miner := applyOverrides(r.baseMiner(), &overrides)
+			if miner.Validate() != nil {
+				continue
+			}
+			key := fmt.Sprint(benchmarkConfig(miner, nil))
+			if measured[key] {
+				continue
+			}
+			measured[key] = true
+
+			if !r.waitForRelease(ctx, deadline) {
+				if ctx.Err() != nil {
+					return nil, fmt.Errorf("%w by %s", errBenchmarkStopped, domain.XMRigStopShutdown)
+				}
+				return nil, errTimeout
+			}
+			run, err := r.benchmarkCandidate(ctx, job, overrides, miner, deadline)
+			if errors.Is(err, errBenchmarkStopped) {
+				if ctx.Err() == nil && !time.Now().Before(deadline) {
+					return nil, errTimeout
+				}
+				return nil, err
+			}
+			scored, score := scoreTuneCandidate(run, job.req)
+			scored.Stage = stage.name
+			scored.Name = candidate.name
+			job.update(func(result *domain.XMRigTune) {
+				result.Candidates = append(result.Candidates, scored)
+			})
+			if scored.Eligible && (best == nil || score > tuneScore(*best, job.req.Objective)) {
+				best = &scored
+				bestOverrides = overrides
+			}
+		}
+		if best == nil {
+			return nil, fmt.Errorf("%w in the %s stage", domain.ErrXMRigTuneNoWinner, stage.name)
+		}
+	}
+
+	profile := domain.XMRigTuneProfile{
+		Threads:     best.Config.Threads,
+		Affinity:    append([]int(nil), best.Config.Affinity...),
+		RandomXMode: best.Config.RandomXMode,
+		HugePages:   best.Config.HugePages,
+		OneGBPages:  best.Config.OneGBPages,
+		Objective:   job.req.Objective,
+		Score:       tuneScore(*best, job.req.Objective),
+		HashrateHS:  *best.HashrateHS,
+		PowerW:      copyFloat(best.PowerW),
+		TempMaxC:    copyFloat(best.TempMaxC),
+		BenchmarkID: best.BenchmarkID,
+		TunedAt:     time.Now().UTC(),
+	}
+	r.applyTuneProfile(profile)
+	return &profile, nil
+}
+
+// waitForRelease blocks while a hold or thermal throttle is active, since a
+// candidate started then would only be skipped. It reports false once ctx is
+// done or the deadline passes.
+func (r *Wrapper) waitForRelease(ctx context.Context, deadline time.Time) bool {
+	for {
+		if ctx.Err() != nil || !time.Now().Before(deadline) {
+			return false
+		}
+		if r.activeHold() == "" {
+			return true
+		}
+		r.sleep(ctx, min(tuneHoldPoll, time.Until(deadline)))
+	}
+}
+
+// benchmarkCandidate runs one benchmark the way a queued run would, keeping
+// it visible in the status while it runs.
+func (r *Wrapper) benchmarkCandidate(ctx context.Context, job *tuneJob, overrides domain.XMRigMinerOverrides, miner MinerConfig, deadline time.Time) (domain.XMRigBenchmark, error) {
+	run, err := newBenchmarkRun(domain.XMRigBenchmarkRequest{
+		Size:      job.req.Size,
+		Overrides: &overrides,
+		Specs:     job.req.Specs,
+	}, miner)
+	if err != nil {
+		return domain.XMRigBenchmark{}, err
+	}
+	run.deadline = deadline
+	r.mu.Lock()
+	r.benchmark = run
+	r.mu.Unlock()
+	err = r.runBenchmark(ctx, run)
+	return run.snapshot(), err
+}
+
+// scoreTuneCandidate rates a finished run. Runs that failed, went over the
+// temperature ceiling or, when tuning per watt, have no power reading are
+// not eligible. Runs a hold stopped are skipped.
+func scoreTuneCandidate(run domain.XMRigBenchmark, req domain.XMRigTuneRequest) (domain.XMRigTuneCandidate, float64) {
+	candidate := domain.XMRigTuneCandidate{
+		BenchmarkID: run.ID,
+		Config:      run.Config,
+		HashrateHS:  copyFloat(run.HashrateHS),
+		PowerW:      copyFloat(run.PowerW),
+		TempMaxC:    peakTemp(run),
+	}
+	if run.HashrateHS != nil && run.PowerW != nil && *run.PowerW > 0 {
+		perJoule := *run.HashrateHS / *run.PowerW
+		candidate.HashesPerJoule = &perJoule
+	}
+	switch {
+	case run.Status == domain.XMRigBenchmarkInvalid:
+		candidate.Skipped = true
+		candidate.Reason = run.Error
+	case run.Status != domain.XMRigBenchmarkDone || run.HashrateHS == nil:
+		candidate.Reason = run.Error
+	case req.MaxTempC != nil && candidate.TempMaxC != nil && *candidate.TempMaxC > *req.MaxTempC:
+		candidate.Reason = fmt.Sprintf("reached %.1f C, above the %.1f C ceiling", *candidate.TempMaxC, *req.MaxTempC)
+	case req.Objective == domain.XMRigTuneHashratePerWatt && candidate.HashesPerJoule == nil:
+		candidate.Reason = "no CPU power reading"
+	default:
+		candidate.Eligible = true
+	}
+	return candidate, tuneScore(candidate, req.Objective)
+}
+
+func tuneScore(candidate domain.XMRigTuneCandidate, objective string) float64 {
+	if objective == domain.XMRigTuneHashratePerWatt {
+		if candidate.HashesPerJoule == nil {
+			return 0
+		}
+		return *candidate.HashesPerJoule
+	}
+	if candidate.HashrateHS == nil {
+		return 0
+	}
+	return *candidate.HashrateHS
+}
+
+// peakTemp is the hottest reading of a run; short runs may end before the
+// first periodic sample.
+func peakTemp(run domain.XMRigBenchmark) *float64 {
+	peak := copyFloat(run.TempMaxC)
+	if run.TempEndC != nil && (peak == nil || *run.TempEndC > *peak) {
+		peak = copyFloat(run.TempEndC)
+	}
+	return peak
+}
+
+// tuneThreadCounts proposes half the cores, every core, every logical CPU and
+// as many threads as the L3 cache fits.
+func tuneThreadCounts(topology specs.Topology) []int {
+	cpus, cores := len(topology.CPUs), topology.Cores()
+	candidates := []int{cores / 2, cores, cpus}
+	if topology.L3Bytes > 0 {
+		candidates = append(candidates, int(topology.L3Bytes/randomxL3PerThread))
+	}
+	seen := make(map[int]bool)
+	var counts []int
+	for _, threads := range candidates {
+		if threads < 1 || threads > cpus || seen[threads] {
+			continue
+		}
+		seen[threads] = true
+		counts = append(counts, threads)
+	}
+	sort.Ints(counts)
+	return counts
+}
+
+// threadCandidates pairs each thread count with xmrig's own placement, one
+// thread per core before SMT siblings, and threads packed onto siblings.
+func threadCandidates(topology specs.Topology, threadCounts []int) []tuneCandidate {
+	var candidates []tuneCandidate
+	for _, threads := range threadCounts {
+		count := threads
+		name := strconv.Itoa(threads) + " threads"
+		if threads == 1 {
+			name = "1 thread"
+		}
+		candidates = append(candidates, tuneCandidate{
+			name:      name + ", unpinned",
+			overrides: domain.XMRigMinerOverrides{Threads: &count, Affinity: []int{}},
+		})
+		spread := spreadAffinity(topology, threads)
+		candidates = append(candidates, tuneCandidate{
+			name:      name + ", one per core",
+			overrides: domain.XMRigMinerOverrides{Threads: &count, Affinity: spread},
+		})
+		if packed := packedAffinity(topology, threads); !sameCPUs(spread, packed) {
+			candidates = append(candidates, tuneCandidate{
+				name:      name + ", packed on siblings",
+				overrides: domain.XMRigMinerOverrides{Threads: &count, Affinity: packed},
+			})
+		}
+	}
+	return candidates
+}
+
+func modeCandidates(best domain.XMRigMinerOverrides, modes []string) []tuneCandidate {
+	candidates := make([]tuneCandidate, 0, len(modes))
+	for _, mode := range modes {
+		overrides := best
+		value := mode
+		overrides.RandomXMode = &value
+		candidates = append(candidates, tuneCandidate{name: "randomx mode " + mode, overrides: overrides})
+	}
+	return candidates
+}
+
+func hugePageCandidates(best domain.XMRigMinerOverrides) []tuneCandidate {
+	options := []struct {
+		name      string
+		hugePages bool
+		oneGB     bool
+	}{
+		{"huge pages with 1GB pages", true, true},
+		{"huge pages without 1GB pages", true, false},
+		{"huge pages off", false, false},
+	}
+	candidates := make([]tuneCandidate, 0, len(options))
+	for _, option := range options {
+		overrides := best
+		hugePages, oneGB := option.hugePages, option.oneGB
+		overrides.HugePages = &hugePages
+		overrides.OneGBPages = &oneGB
+		candidates = append(candidates, tuneCandidate{name: option.name, overrides: overrides})
+	}
+	return candidates
+}
+
+// coreSiblings groups logical CPUs by physical core, cores in package and
+// core order.
+func coreSiblings(topology specs.Topology) [][]int {
+	index := make(map[[2]int]int)
+	var keys [][2]int
+	var siblings [][]int
+	for _, cpu := range topology.CPUs {
+		key := [2]int{cpu.Package, cpu.Core}
+		i, ok := index[key]
+		if !ok {
+			i = len(siblings)
+			index[key] = i
+			keys = append(keys, key)
+			siblings = append(siblings, nil)
+		}
+		siblings[i] = append(siblings[i], cpu.ID)
+	}
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool {
+		ka, kb := keys[order[a]], keys[order[b]]
+		if ka[0] != kb[0] {
+			return ka[0] < kb[0]
+		}
+		return ka[1] < kb[1]
+	})
+	sorted := make([][]int, 0, len(order))
+	for _, i := range order {
+		sorted = append(sorted, siblings[i])
+	}
+	return sorted
+}
+
+func spreadAffinity(topology specs.Topology, threads int) []int {
+	cores := coreSiblings(topology)
+	affinity := make([]int, 0, threads)
+	for round := 0; len(affinity) < threads; round++ {
+		for _, siblings := range cores {
+			if round < len(siblings) && len(affinity) < threads {
+				affinity = append(affinity, siblings[round])
+			}
+		}
+	}
+	return affinity
+}
+
+func packedAffinity(topology specs.Topology, threads int) []int {
+	affinity := make([]int, 0, threads)
+	for _, siblings := range coreSiblings(topology) {
+		for _, cpu := range siblings {
+			if len(affinity) < threads {
+				affinity = append(affinity, cpu)
+			}
+		}
+	}
+	return affinity
+}
+
+func sameCPUs(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := append([]int(nil), a...)
+	sortedB := append([]int(nil), b...)
+	sort.Ints(sortedA)
+	sort.Ints(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func copyTuneCandidate(candidate domain.XMRigTuneCandidate) domain.XMRigTuneCandidate {
+	candidate.Config.Affinity = append([]int(nil), candidate.Config.Affinity...)
+	candidate.Config.Args = append([]string(nil), candidate.Config.Args...)
+	candidate.HashrateHS = copyFloat(candidate.HashrateHS)
+	candidate.PowerW = copyFloat(candidate.PowerW)
+	candidate.HashesPerJoule = copyFloat(candidate.HashesPerJoule)
+	candidate.TempMaxC = copyFloat(candidate.TempMaxC)
+	return candidate
+}
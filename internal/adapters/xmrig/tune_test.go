@@ -0,0 +1,192 @@
+package xmrig
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/restartfu/grid-node/internal/domain"
+	"github.com/restartfu/grid-node/internal/specs"
+)
+
+// smtTopology is one package of cores with two siblings each.
+func smtTopology(cores int, l3 int64) specs.Topology {
+	topology := specs.Topology{L3Bytes: l3}
+	for i := 0; i < cores*2; i++ {
+		topology.CPUs = append(topology.CPUs, specs.CPU{ID: i, Core: i % cores})
+	}
+	return topology
+}
+
+func TestTuneThreadCounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		topology specs.Topology
+		want     []int
+	}{
+		{"8 cores with smt", smtTopology(8, 32<<20), []int{4, 8, 16}},
+		{"l3 fits fewer threads", smtTopology(8, 8<<20), []int{4, 8, 16}},
+		{"l3 fits between", smtTopology(8, 12<<20), []int{4, 6, 8, 16}},
+		{"no l3", smtTopology(4, 0), []int{2, 4, 8}},
+		{"single cpu", specs.Topology{CPUs: []specs.CPU{{ID: 0}}}, []int{1}},
+		{"l3 beyond cpus", smtTopology(2, 64<<20), []int{1, 2, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tuneThreadCounts(tt.topology); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("tuneThreadCounts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreTuneCandidate(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	done := func(hashrate, power, temp *float64) domain.XMRigBenchmark {
+		return domain.XMRigBenchmark{ID: "b1", Status: domain.XMRigBenchmarkDone, HashrateHS: hashrate, PowerW: power, TempMaxC: temp}
+	}
+	hashrate := domain.XMRigTuneRequest{Objective: domain.XMRigTuneHashrate, MaxTempC: f(85)}
+	perWatt := domain.XMRigTuneRequest{Objective: domain.XMRigTuneHashratePerWatt}
+	tests := []struct {
+		name     string
+		run      domain.XMRigBenchmark
+		req      domain.XMRigTuneRequest
+		eligible bool
+		skipped  bool
+		reason   string
+		score    float64
+	}{
+		{"hashrate", done(f(6000), f(100), f(70)), hashrate, true, false, "", 6000},
+		{"per watt", done(f(6000), f(100), nil), perWatt, true, false, "", 60},
+		{"over the ceiling", done(f(6000), nil, f(90)), hashrate, false, false, "reached 90.0 C, above the 85.0 C ceiling", 6000},
+		{
+			"end temperature over the ceiling",
+			domain.XMRigBenchmark{Status: domain.XMRigBenchmarkDone, HashrateHS: f(6000), TempMaxC: f(80), TempEndC: f(86)},
+			hashrate, false, false, "reached 86.0 C, above the 85.0 C ceiling", 6000,
+		},
+		{"no power reading", done(f(6000), nil, nil), perWatt, false, false, "no CPU power reading", 0},
+		{"zero power reading", done(f(6000), f(0), nil), perWatt, false, false, "no CPU power reading", 0},
+		{
+			"failed",
+			domain.XMRigBenchmark{Status: domain.XMRigBenchmarkFailed, Error: "no result within 1h0m0s"},
+			hashrate, false, false, "no result within 1h0m0s", 0,
+		},
+		{
+			"held",
+			domain.XMRigBenchmark{Status: domain.XMRigBenchmarkInvalid, Error: "benchmark held: stopped by the idle hold"},
+			hashrate, false, true, "benchmark held: stopped by the idle hold", 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidate, score := scoreTuneCandidate(tt.run, tt.req)
+			if candidate.Eligible != tt.eligible || candidate.Skipped != tt.skipped || candidate.Reason != tt.reason {
+				t.Fatalf("eligible %t, skipped %t, reason %q; want %t, %t, %q", candidate.Eligible, candidate.Skipped, candidate.Reason, tt.eligible, tt.skipped, tt.reason)
+			}
+			if score != tt.score {
+				t.Fatalf("score = %v, want %v", score, tt.score)
+			}
+		})
+	}
+}
+
+// newTuneWrapper tunes on a single CPU with a stand-in xmrig whose first
+// benchmark hangs and whose later ones report a result right away.
+// It returns the file the first benchmark creates once it is running.
+func newTuneWrapper(t *testing.T, timeout time.Duration) (*Wrapper, string) {
+	t.Helper()
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "xmrig")
+	started := filepath.Join(dir, "started")
+	script := "#!/bin/sh\n" +
+		"if [ \"$1\" = --version ]; then echo 'XMRig 6.21.0'; exit 0; fi\n" +
+		"if [ ! -e " + started + " ]; then touch " + started + "; exec sleep 30; fi\n" +
+		"echo '[2024-01-01 10:02:41.432]  bench    start benchmark hashes 1M algo rx/0'\n" +
+		"echo '[2024-01-01 10:03:27.146]  bench    benchmark finished in 45.711 seconds (1000.0 h/s) hash sum = 9B39A5E5D2E4F3C0'\n"
+	if err := os.WriteFile(binary, []byte(script), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	r := NewWrapper(io.Discard, Config{
+		BinaryPath:      binary,
+		ConfigPath:      filepath.Join(dir, "run", "xmrig.json"),
+		StopGracePeriod: time.Second,
+		TuneTimeout:     timeout,
+	})
+	r.readTopology = func() specs.Topology {
+		return specs.Topology{CPUs: []specs.CPU{{ID: 0}}}
+	}
+	return r, started
+}
+
+func waitForTune(t *testing.T, r *Wrapper, done func(domain.XMRigTune) bool) domain.XMRigTune {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		if job := r.Tuning().Job; job != nil && done(*job) {
+			return *job
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("tune state = %+v", r.Tuning().Job)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestTuneSkipsHeldCandidate(t *testing.T) {
+	r, started := newTuneWrapper(t, time.Minute)
+	job, err := r.queueTune(domain.XMRigTuneRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go r.runTune(context.Background(), job)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !fileExists(started) || r.pid() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("first candidate did not start")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	r.setHold(holdIdle, true)
+	held := waitForTune(t, r, func(tune domain.XMRigTune) bool { return len(tune.Candidates) == 1 })
+	if first := held.Candidates[0]; !first.Skipped || first.Eligible {
+		t.Fatalf("first candidate = %+v, want skipped", first)
+	}
+	// The sweep waits out the hold instead of skipping every candidate.
+	time.Sleep(50 * time.Millisecond)
+	if got := len(r.Tuning().Job.Candidates); got != 1 {
+		t.Fatalf("%d candidates ran during the hold", got)
+	}
+
+	r.setHold(holdIdle, false)
+	result := waitForTune(t, r, func(tune domain.XMRigTune) bool { return tune.EndTime != nil })
+	if result.Status != domain.XMRigTuneDone || result.Profile == nil {
+		t.Fatalf("tune = %s %q, want done with a profile", result.Status, result.Error)
+	}
+	for _, candidate := range result.Candidates[1:] {
+		if candidate.Skipped || !candidate.Eligible {
+			t.Fatalf("candidate %q = %+v, want eligible", candidate.Name, candidate)
+		}
+	}
+}
+
+func TestTuneTimeout(t *testing.T) {
+	r, _ := newTuneWrapper(t, 200*time.Millisecond)
+	job, err := r.queueTune(domain.XMRigTuneRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.runTune(context.Background(), job)
+	result := job.snapshot()
+	if result.Status != domain.XMRigTuneFailed || !strings.Contains(result.Error, "did not finish within 200ms") {
+		t.Fatalf("tune = %s %q, want failed by the timeout", result.Status, result.Error)
+	}
+	if r.pid() != 0 {
+		t.Fatal("candidate still running after the timeout")
+	}
+}
@@ -0,0 +1,157 @@
+package xmrig
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/restartfu/grid-node/internal/domain"
+	"github.com/restartfu/grid-node/internal/observability"
+)
+
+type tuneProfileRecord struct {
+	Threads     int       `json:"threads"`
+	Affinity    []int     `json:"affinity,omitempty"`
+	RandomXMode string    `json:"randomx_mode"`
+	HugePages   bool      `json:"huge_pages"`
+	OneGBPages  bool      `json:"1gb_pages"`
+	Objective   string    `json:"objective"`
+	Score       float64   `json:"score"`
+	HashrateHS  float64   `json:"hashrate_hs"`
+	PowerW      *float64  `json:"power_w,omitempty"`
+	TempMaxC    *float64  `json:"temp_max_c,omitempty"`
+	BenchmarkID string    `json:"benchmark_id"`
+	TunedAt     time.Time `json:"tuned_at"`
+}
+
+// loadTuneProfile reads the saved profile; a missing file or empty path
+// means no profile.
+func loadTuneProfile(path string) (*domain.XMRigTuneProfile, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var record tuneProfileRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, err
+	}
+	profile := domain.XMRigTuneProfile{
+		Threads:     record.Threads,
+		Affinity:    record.Affinity,
+		RandomXMode: record.RandomXMode,
+		HugePages:   record.HugePages,
+		OneGBPages:  record.OneGBPages,
+		Objective:   record.Objective,
+		Score:       record.Score,
+		HashrateHS:  record.HashrateHS,
+		PowerW:      record.PowerW,
+		TempMaxC:    record.TempMaxC,
+		BenchmarkID: record.BenchmarkID,
+		TunedAt:     record.TunedAt,
+	}
+	return &profile, nil
+}
+
+func saveTuneProfile(path string, profile domain.XMRigTuneProfile) error {
+	data, err := json.MarshalIndent(tuneProfileRecord{
+		Threads:     profile.Threads,
+		Affinity:    profile.Affinity,
+		RandomXMode: profile.RandomXMode,
+		HugePages:   profile.HugePages,
+		OneGBPages:  profile.OneGBPages,
+		Objective:   profile.Objective,
+		Score:       profile.Score,
+		HashrateHS:  profile.HashrateHS,
+		PowerW:      profile.PowerW,
+		TempMaxC:    profile.TempMaxC,
+		BenchmarkID: profile.BenchmarkID,
+		TunedAt:     profile.TunedAt,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// applyTuneProfile layers the profile over the configured miner settings
+// from the next launch on and saves it when a file is configured.
+func (r *Wrapper) applyTuneProfile(profile domain.XMRigTuneProfile) {
+	r.mu.Lock()
+	r.tuneProfile = &profile
+	r.mu.Unlock()
+	r.state.setRandomXMode(randomXMode(r.baseMiner(), r.config.ExtraArgs))
+	if r.config.TuneFile == "" {
+		return
+	}
+	if err := saveTuneProfile(r.config.TuneFile, profile); err != nil {
+		log.Printf("xmrig tune profile: %v", err)
+		observability.CaptureError(err, map[string]string{
+			"component": "xmrig",
+			"operation": "tune_profile_save",
+		}, map[string]interface{}{
+			"path": r.config.TuneFile,
+		})
+	}
+}
+
+// baseMiner is the configured miner with the tuned profile applied.
+func (r *Wrapper) baseMiner() MinerConfig {
+	r.mu.Lock()
+	profile := r.tuneProfile
+	r.mu.Unlock()
+	if profile == nil {
+		return r.config.Miner
+	}
+	return applyOverrides(r.config.Miner, tuneOverrides(*profile))
+}
+
+func (r *Wrapper) tuneProfileSnapshot() *domain.XMRigTuneProfile {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.tuneProfile == nil {
+		return nil
+	}
+	profile := copyTuneProfile(*r.tuneProfile)
+	return &profile
+}
+
+func tuneOverrides(profile domain.XMRigTuneProfile) *domain.XMRigMinerOverrides {
+	threads, mode := profile.Threads, profile.RandomXMode
+	hugePages, oneGB := profile.HugePages, profile.OneGBPages
+	return &domain.XMRigMinerOverrides{
+		Threads:     &threads,
+		Affinity:    append([]int{}, profile.Affinity...),
+		RandomXMode: &mode,
+		HugePages:   &hugePages,
+		OneGBPages:  &oneGB,
+	}
+}
+
+func copyTuneProfile(profile domain.XMRigTuneProfile) domain.XMRigTuneProfile {
+	profile.Affinity = append([]int(nil), profile.Affinity...)
+	profile.PowerW = copyFloat(profile.PowerW)
+	profile.TempMaxC = copyFloat(profile.TempMaxC)
+	return profile
+}
+
+func (s *state) setRandomXMode(mode string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.randomxMode = mode
+}
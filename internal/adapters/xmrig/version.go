@@ -0,0 +1,111 @@
+package xmrig
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/restartfu/grid-node/internal/domain"
+)
+
+// versionTimeout bounds xmrig --version, which exits right away.
+const versionTimeout = 10 * time.Second
+
+var (
+	versionRegex  = regexp.MustCompile(`(?m)^\s*XMRig(?:-\S+)?\s+v?(\d+(?:\.\d+){0,2}\S*)`)
+	builtRegex    = regexp.MustCompile(`(?m)^\s*built on (.+?) with (.+?)\s*$`)
+	featuresRegex = regexp.MustCompile(`(?m)^\s*features:\s*(.+?)\s*$`)
+	libraryRegex  = regexp.MustCompile(`(?m)^\s*(libuv|OpenSSL|LibreSSL|BoringSSL|hwloc)/(\S+)\s*$`)
+)
+
+// Version is a major.minor.patch xmrig version.
+type Version [3]int
+
+// ParseVersion accepts "6.21.0", "v6.21" or "6.21.0-mo1"; anything after a
+// dash or plus is ignored.
+func ParseVersion(value string) (Version, error) {
+	raw := strings.TrimPrefix(strings.TrimSpace(value), "v")
+	if i := strings.IndexAny(raw, "-+"); i >= 0 {
+		raw = raw[:i]
+	}
+	parts := strings.Split(raw, ".")
+	if raw == "" || len(parts) > 3 {
+		return Version{}, fmt.Errorf("invalid version %q", value)
+	}
+	var version Version
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid version %q", value)
+		}
+		version[i] = n
+	}
+	return version, nil
+}
+
+func (v Version) Less(other Version) bool {
+	for i := range v {
+		if v[i] != other[i] {
+			return v[i] < other[i]
+		}
+	}
+	return false
+}
+
+func checkMinVersion(version, minVersion string) error {
+	required, err := ParseVersion(minVersion)
+	if err != nil {
+		return err
+	}
+	actual, err := ParseVersion(version)
+	if err != nil {
+		return err
+	}
+	if actual.Less(required) {
+		return fmt.Errorf("version %s is older than the required %s", version, minVersion)
+	}
+	return nil
+}
+
+// probeBinary runs xmrig --version and parses its banner.
+func probeBinary(ctx context.Context, path string) (domain.XMRigBinary, error) {
+	output, err := exec.CommandContext(ctx, path, "--version").CombinedOutput()
+	if err != nil {
+		return domain.XMRigBinary{DetectedAt: time.Now().UTC()}, fmt.Errorf("%s --version: %w", path, err)
+	}
+	return parseVersionOutput(string(output))
+}
+
+func parseVersionOutput(output string) (domain.XMRigBinary, error) {
+	binary := domain.XMRigBinary{DetectedAt: time.Now().UTC()}
+	match := versionRegex.FindStringSubmatch(output)
+	if match == nil {
+		return binary, fmt.Errorf("no version in xmrig --version output %q", strings.TrimSpace(output))
+	}
+	binary.Version = match[1]
+	if match := builtRegex.FindStringSubmatch(output); match != nil {
+		binary.BuiltOn = match[1]
+		binary.Compiler = match[2]
+	}
+	if match := featuresRegex.FindStringSubmatch(output); match != nil {
+		binary.Features = strings.Fields(match[1])
+	}
+	for _, match := range libraryRegex.FindAllStringSubmatch(output, -1) {
+		library := match[1] + "/" + match[2]
+		switch match[1] {
+		case "libuv":
+			binary.LibUV = library
+		case "hwloc":
+			binary.HWLoc = true
+			binary.HWLocVersion = library
+		default:
+			binary.TLS = true
+			binary.TLSVersion = library
+		}
+	}
+	return binary, nil
+}
@@ -0,0 +1,123 @@
+package xmrig
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/restartfu/grid-node/internal/domain"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Version
+		err   bool
+	}{
+		{value: "6.21.0", want: Version{6, 21, 0}},
+		{value: "v6.21", want: Version{6, 21, 0}},
+		{value: "6.21.0-mo1", want: Version{6, 21, 0}},
+		{value: "6.22.0+git.abc123", want: Version{6, 22, 0}},
+		{value: " 6 ", want: Version{6, 0, 0}},
+		{value: "", err: true},
+		{value: "6.", err: true},
+		{value: "6.x.1", err: true},
+		{value: "6.21.0.1", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseVersion(tt.value)
+			if (err != nil) != tt.err || got != tt.want {
+				t.Fatalf("ParseVersion(%q) = %v, %v; want %v, error %t", tt.value, got, err, tt.want, tt.err)
+			}
+		})
+	}
+}
+
+func TestCheckMinVersion(t *testing.T) {
+	tests := []struct {
+		version, min string
+		err          bool
+	}{
+		{"6.21.0", "6.21.0", false},
+		{"6.21.1", "6.21", false},
+		{"6.9.0", "6.21.0", true},
+		{"5.11.4", "6", true},
+		{"6.21.0-mo1", "6.21.0", false},
+		{"dev", "6.21.0", true},
+	}
+	for _, tt := range tests {
+		if err := checkMinVersion(tt.version, tt.min); (err != nil) != tt.err {
+			t.Errorf("checkMinVersion(%q, %q) = %v, want error %t", tt.version, tt.min, err, tt.err)
+		}
+	}
+}
+
+func TestParseVersionOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   domain.XMRigBinary
+		err    bool
+	}{
+		{
+			name: "release build",
+			output: "XMRig 6.21.0\n" +
+				" built on Nov 24 2023 with GCC 13.2.1\n" +
+				" features: 64-bit AVX2 AES\n" +
+				"\n" +
+				"libuv/1.44.2\n" +
+				"OpenSSL/3.0.12\n" +
+				"hwloc/2.9.3\n",
+			want: domain.XMRigBinary{
+				Version:      "6.21.0",
+				BuiltOn:      "Nov 24 2023",
+				Compiler:     "GCC 13.2.1",
+				Features:     []string{"64-bit", "AVX2", "AES"},
+				LibUV:        "libuv/1.44.2",
+				TLS:          true,
+				TLSVersion:   "OpenSSL/3.0.12",
+				HWLoc:        true,
+				HWLocVersion: "hwloc/2.9.3",
+			},
+		},
+		{
+			name: "fork without tls or hwloc",
+			output: "XMRig-MO 6.21.0-mo1\r\n" +
+				" built on Jan  3 2024 with clang 15.0.0\r\n" +
+				" features: 64-bit AES\r\n" +
+				"\r\n" +
+				"libuv/1.46.0\r\n",
+			want: domain.XMRigBinary{
+				Version:  "6.21.0-mo1",
+				BuiltOn:  "Jan  3 2024",
+				Compiler: "clang 15.0.0",
+				Features: []string{"64-bit", "AES"},
+				LibUV:    "libuv/1.46.0",
+			},
+		},
+		{
+			name:   "banner only",
+			output: "XMRig 6.18.1\n",
+			want:   domain.XMRigBinary{Version: "6.18.1"},
+		},
+		{name: "truncated", output: "XMRig", err: true},
+		{name: "not xmrig", output: "sh: 1: xmrig: not found\n", err: true},
+		{name: "empty", output: "", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseVersionOutput(tt.output)
+			if (err != nil) != tt.err {
+				t.Fatalf("parseVersionOutput() error = %v, want error %t", err, tt.err)
+			}
+			if got.DetectedAt.IsZero() || time.Since(got.DetectedAt) > time.Minute {
+				t.Fatalf("DetectedAt = %v, want now", got.DetectedAt)
+			}
+			got.DetectedAt = time.Time{}
+			if !tt.err && !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("parseVersionOutput() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
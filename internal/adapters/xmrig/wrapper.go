@@ -8,17 +8,47 @@ import (
 	"log"
 	"os"
 	"os/exec"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/restartfu/grid-node/internal/domain"
 	"github.com/restartfu/grid-node/internal/observability"
+	"github.com/restartfu/grid-node/internal/specs"
 )
 
 type Wrapper struct {
-	state  *state
-	output io.Writer
-	config Config
+	state      *state
+	output     io.Writer
+	config     Config
+	restarts   *restartPolicy
+	benchmarks *benchmarkStore
+
+	mu           sync.Mutex
+	process      *process
+	threadLimit  int
+	scheduleArgs []string
+	holds        map[string]struct{}
+	benchmark    *benchmarkRun
+	tune         *tuneJob
+	lastTune     *tuneJob
+	tuneProfile  *domain.XMRigTuneProfile
+	wake         chan struct{}
+	readTemp     func() (float64, bool)
+	readPower    func() (float64, bool)
+	readTopology func() specs.Topology
+}
+
+type process struct {
+	cmd    *exec.Cmd
+	cancel context.CancelFunc
+	reason string
+}
+
+// exitInfo is how the last xmrig process ended.
+type exitInfo struct {
+	code   int
+	signal string
 }
 
 func NewWrapper(output io.Writer, config Config) *Wrapper {
@@ -26,41 +56,205 @@ func NewWrapper(output io.Writer, config Config) *Wrapper {
 		output = os.Stdout
 	}
 	config = normalizeConfig(config)
-	return &Wrapper{
-		state:  newState(),
-		output: output,
-		config: config,
+	state := newState(randomXMode(config.Miner, config.ExtraArgs), config.LogBufferSize, config.HashrateHistorySize)
+	if config.LogFile.Path != "" {
+		file, lastSeq, err := openLogFile(config.LogFile)
+		if err != nil {
+			log.Printf("xmrig log file: %v", err)
+			observability.CaptureError(err, map[string]string{
+				"component": "xmrig",
+				"operation": "log_file_open",
+			}, map[string]interface{}{
+				"path": config.LogFile.Path,
+			})
+		} else {
+			state.file = file
+			state.logs.lastSeq = lastSeq
+		}
+	}
+	benchmarks, err := openBenchmarkStore(config.BenchmarkFile)
+	if err != nil {
+		log.Printf("xmrig benchmark store: %v", err)
+		observability.CaptureError(err, map[string]string{
+			"component": "xmrig",
+			"operation": "benchmark_store_open",
+		}, map[string]interface{}{
+			"path": config.BenchmarkFile,
+		})
+	}
+	r := &Wrapper{
+		state:        state,
+		output:       output,
+		config:       config,
+		restarts:     newRestartPolicy(config),
+		benchmarks:   benchmarks,
+		wake:         make(chan struct{}, 1),
+		holds:        make(map[string]struct{}),
+		readTemp:     specs.ReadCPUTempCelsius,
+		readPower:    specs.ReadCPUWattageValue,
+		readTopology: specs.ReadTopology,
+	}
+	profile, err := loadTuneProfile(config.TuneFile)
+	if err == nil && profile != nil {
+		// A profile from different hardware, e.g. with fewer CPUs, is dropped.
+		err = applyOverrides(config.Miner, tuneOverrides(*profile)).Validate()
+	}
+	if err != nil {
+		log.Printf("xmrig tune profile: %v", err)
+		observability.CaptureError(err, map[string]string{
+			"component": "xmrig",
+			"operation": "tune_profile_open",
+		}, map[string]interface{}{
+			"path": config.TuneFile,
+		})
+	} else if profile != nil {
+		r.tuneProfile = profile
+		state.randomxMode = randomXMode(r.baseMiner(), config.ExtraArgs)
 	}
+	if config.Proxy != nil {
+		config.Proxy.OnUpstreamError(r.handleProxyError)
+	}
+	return r
 }
 
 func (r *Wrapper) Start(ctx context.Context) {
+	r.detectBinary(ctx)
+	if r.config.Schedule != nil {
+		now := time.Now()
+		r.applySchedule(r.config.Schedule.At(now), now)
+		go r.followSchedule(ctx)
+	}
+	if r.config.Thermal.enabled() {
+		go r.guardThermal(ctx)
+	}
+	if r.config.Idle.Enabled {
+		go r.guardIdle(ctx)
+	}
+	if failoverEnabled(r.config.Miner) {
+		go r.retryPrimary(ctx)
+	}
+	if r.config.Proxy != nil {
+		go r.serveProxy(ctx)
+	}
+	if r.config.Probe.enabled() {
+		go r.probePools(ctx)
+	}
 	r.run(ctx)
+	r.state.closeLogFile()
 }
 
 func (r *Wrapper) Status() domain.XMRigStatus {
-	return r.state.snapshot()
+	status := r.state.snapshot()
+	if r.config.Thermal.enabled() {
+		status.Thermal = r.state.thermalSnapshot(r.config.Thermal)
+	}
+	status.MiningMode = domain.XMRigModeAlways
+	if r.config.Idle.Enabled {
+		status.MiningMode = domain.XMRigModeIdle
+		status.Idle = r.state.idleSnapshot(r.config.Idle)
+	}
+	if failoverEnabled(r.config.Miner) {
+		status.Failover = r.state.failoverSnapshot(r.config.Failover, r.config.Miner.Pools)
+	}
+	if r.config.Proxy != nil {
+		status.Proxy = proxySnapshot(r.config.Proxy.Stats())
+	}
+	status.PoolProbe = r.state.probeSnapshot()
+	status.Binary = r.state.binarySnapshot()
+	status.Resources = r.state.resourcesSnapshot()
+	status.RandomX = r.state.randomxSnapshot(r.baseMiner().RandomX.OneGBPages)
+	if run := r.pendingBenchmark(); run != nil {
+		benchmark := run.snapshot()
+		status.Benchmark = &benchmark
+	}
+	status.TuneProfile = r.tuneProfileSnapshot()
+	return status
+}
+
+func (r *Wrapper) Logs(query domain.XMRigLogQuery) (domain.XMRigLogPage, error) {
+	if query.History {
+		if r.state.file == nil {
+			return domain.XMRigLogPage{}, domain.ErrXMRigNoLogFile
+		}
+		return r.state.file.query(query)
+	}
+	query.Limit = r.normalizeLogCount(query.Limit)
+	return r.state.queryLogs(query), nil
+}
+
+// SubscribeLogs returns up to backlog recent lines and a channel receiving an
+// XMRigLogEvent for every line recorded afterwards. The channel is closed
+// when the subscriber falls behind; the returned func releases the
+// subscription.
+func (r *Wrapper) SubscribeLogs(backlog int) ([]domain.XMRigLogEntry, <-chan domain.XMRigEvent, func()) {
+	logs, sub := r.state.subscribeLogs(r.normalizeLogCount(backlog))
+	return logs, sub.ch, func() {
+		r.state.events.unsubscribe(sub)
+	}
 }
 
-func (r *Wrapper) Logs(n int) []domain.XMRigLogEntry {
-	return r.state.lastLogs(normalizeLogCount(n))
+// HashrateHistory downsamples the recorded 10s hashrate samples.
+func (r *Wrapper) HashrateHistory(query domain.XMRigHashrateQuery) domain.XMRigHashrateHistory {
+	return r.state.hashrateHistory(query)
 }
 
 type state struct {
-	mu        sync.RWMutex
-	running   bool
-	hashrate  float64
-	lastLog   time.Time
-	lastStart time.Time
-	lastExit  time.Time
-	lastError string
-	logs      []domain.XMRigLogEntry
-	logIndex  int
-	logCount  int
-}
-
-func newState() *state {
+	mu           sync.RWMutex
+	running      bool
+	paused       bool
+	desired      string
+	source       string
+	hashrate     hashrateInfo
+	last15m      *float64
+	last15mAt    time.Time
+	history      *hashrateRing
+	thermal      thermalState
+	idle         idleState
+	failover     failoverState
+	probe        probeState
+	binary       *domain.XMRigBinary
+	binaryFile   binaryFile
+	resources    resourceState
+	randomx      randomxState
+	scheduleAt   time.Time
+	threads      []float64
+	pool         string
+	uptime       int64
+	difficulty   uint64
+	accepted     uint64
+	rejected     uint64
+	stale        uint64
+	height       uint64
+	lastJob      time.Time
+	shareLatency time.Duration
+	randomxMode  string
+	stderrTail   []string
+	restarts     int
+	backoff      time.Duration
+	crashLooping bool
+	nextRestart  time.Time
+	stopReason   string
+	exit         exitInfo
+	lastLog      time.Time
+	lastStart    time.Time
+	lastExit     time.Time
+	lastError    string
+	logs         *logRing
+	file         *logFile
+	events       *eventBus
+	// connectedPool is the pool xmrig is connected to, empty while it is
+	// not, for pool connection events.
+	connectedPool string
+}
+
+func newState(randomxMode string, logBufferSize, historySize int) *state {
 	return &state{
-		logs: make([]domain.XMRigLogEntry, maxLogs),
+		desired:     domain.XMRigDesiredRunning,
+		source:      domain.XMRigSourceLog,
+		randomxMode: randomxMode,
+		logs:        newLogRing(logBufferSize),
+		history:     newHashrateRing(historySize),
+		events:      newEventBus(),
 	}
 }
 
@@ -69,14 +263,56 @@ func (s *state) snapshot() domain.XMRigStatus {
 	defer s.mu.RUnlock()
 
 	response := domain.XMRigStatus{
-		Running:    s.running,
-		HashrateHS: s.hashrate,
-		LastError:  s.lastError,
+		Running:        s.running,
+		Paused:         s.paused,
+		DesiredState:   s.desired,
+		StatusSource:   s.source,
+		Hashrate60sHS:  copyFloat(s.hashrate.sixtySec),
+		Hashrate15mHS:  copyFloat(s.hashrate.fifteenMin),
+		HashrateMaxHS:  copyFloat(s.hashrate.max),
+		Pool:           s.pool,
+		UptimeSeconds:  s.uptime,
+		Difficulty:     s.difficulty,
+		SharesAccepted: s.accepted,
+		SharesRejected: s.rejected,
+		SharesStale:    s.stale,
+		BlockHeight:    s.height,
+		ShareLatencyMS: s.shareLatency.Milliseconds(),
+		RandomXMode:    s.randomxMode,
+		RestartCount:   s.restarts,
+		BackoffMS:      s.backoff.Milliseconds(),
+		CrashLooping:   s.crashLooping,
+		StopReason:     s.stopReason,
+		ExitSignal:     s.exit.signal,
+		LastError:      s.lastError,
+	}
+	if s.hashrate.tenSec != nil {
+		response.HashrateHS = *s.hashrate.tenSec
+	}
+	if s.last15m != nil {
+		response.LastHashrate15mHS = copyFloat(s.last15m)
+		timestamp := s.last15mAt
+		response.LastHashrate15mTime = &timestamp
+	}
+	if s.stopReason != "" && s.exit.signal == "" {
+		code := s.exit.code
+		response.ExitCode = &code
+	}
+	if !s.nextRestart.IsZero() {
+		timestamp := s.nextRestart
+		response.NextRestartTime = &timestamp
+	}
+	if len(s.threads) > 0 {
+		response.ThreadsHS = append([]float64(nil), s.threads...)
 	}
 	if !s.lastLog.IsZero() {
 		timestamp := s.lastLog
 		response.LastLogTime = &timestamp
 	}
+	if !s.lastJob.IsZero() {
+		timestamp := s.lastJob
+		response.LastJobTime = &timestamp
+	}
 	if !s.lastStart.IsZero() {
 		timestamp := s.lastStart
 		response.LastStartTime = &timestamp
@@ -88,84 +324,306 @@ func (s *state) snapshot() domain.XMRigStatus {
 	return response
 }
 
-func (s *state) recordStart(at time.Time) {
+// recordStart resets the per-process state and returns the generation that
+// the new process's log lines and API results are tagged with.
+func (s *state) recordStart(at time.Time, pid int) uint64 {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.events.publish(domain.XMRigStartedEvent{Time: at, PID: pid})
 	s.running = true
 	s.lastStart = at
 	s.lastError = ""
+	s.source = domain.XMRigSourceLog
+	s.accepted = 0
+	s.rejected = 0
+	s.stale = 0
+	s.stderrTail = nil
+	s.randomx = randomxState{}
+	s.nextRestart = time.Time{}
+	s.resetPoolFailuresLocked()
+	s.failover.generation++
+	return s.failover.generation
 }
 
-func (s *state) recordExit(at time.Time, err error) {
+func (s *state) recordExit(at time.Time, err error, reason string, exit exitInfo) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	wasRunning := s.running
 	s.running = false
+	s.stopReason = reason
+	s.exit = exit
+	s.paused = false
 	s.lastExit = at
-	s.hashrate = 0
-	if err != nil {
-		s.lastError = err.Error()
-	} else {
+	s.hashrate = hashrateInfo{}
+	s.threads = nil
+	s.uptime = 0
+	s.resources.current = nil
+	s.source = domain.XMRigSourceLog
+	switch {
+	case err == nil:
 		s.lastError = ""
+	case len(s.stderrTail) > 0:
+		s.lastError = fmt.Sprintf("%v: %s", err, strings.Join(s.stderrTail, "; "))
+	default:
+		s.lastError = err.Error()
+	}
+	if wasRunning {
+		s.setPoolConnectedLocked(s.pool, false, "", at)
+		event := domain.XMRigExitedEvent{Time: at, Reason: reason, Signal: exit.signal, Error: s.lastError}
+		if exit.signal == "" {
+			code := exit.code
+			event.Code = &code
+		}
+		s.events.publish(event)
 	}
 }
 
-func (s *state) recordLine(line string, at time.Time, hasHashrate bool, hashrate float64) {
+// recordRestartScheduled stores the pending backoff and returns whether xmrig
+// was already crash looping before this exit.
+func (s *state) recordRestartScheduled(delay time.Duration, crashLooping bool, at time.Time) bool {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.lastLog = at
-	s.logs[s.logIndex] = domain.XMRigLogEntry{
-		Time: at,
-		Line: line,
-	}
-	s.logIndex = (s.logIndex + 1) % maxLogs
-	if s.logCount < maxLogs {
-		s.logCount++
-	}
-	if hasHashrate {
-		s.hashrate = hashrate
+	wasLooping := s.crashLooping
+	s.restarts++
+	s.backoff = delay
+	s.crashLooping = crashLooping
+	s.nextRestart = at
+	return wasLooping
+}
+
+func (s *state) recordStable() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.backoff = 0
+	s.crashLooping = false
+}
+
+func (s *state) stderrLines() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]string(nil), s.stderrTail...)
+}
+
+func (s *state) setDesired(desired string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.desired = desired
+	if desired != domain.XMRigDesiredRunning {
+		s.nextRestart = time.Time{}
 	}
 }
 
-func (s *state) lastLogs(count int) []domain.XMRigLogEntry {
+func (s *state) desiredState() string {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	if count > s.logCount {
-		count = s.logCount
+	return s.desired
+}
+
+func (s *state) setPaused(paused bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.paused = paused
+}
+
+// recordLine records a log line. Pool errors only count towards failover
+// when generation is still current, so a dying process's last errors do not
+// count against the pool it was switched away from.
+func (s *state) recordLine(line, stream string, at time.Time, info lineInfo, generation uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastLog = at
+	entry := s.logs.add(domain.XMRigLogEntry{
+		Time:   at,
+		Stream: stream,
+		Line:   line,
+	})
+	s.events.publish(domain.XMRigLogEvent{Entry: entry})
+	if s.file != nil {
+		s.file.enqueue(entry)
+	}
+	if stream == domain.XMRigStreamStderr {
+		s.stderrTail = append(s.stderrTail, line)
+		if len(s.stderrTail) > maxStderrTail {
+			s.stderrTail = s.stderrTail[len(s.stderrTail)-maxStderrTail:]
+		}
 	}
-	if count == 0 {
-		return []domain.XMRigLogEntry{}
+	if info.hashrate != nil && s.source == domain.XMRigSourceLog {
+		s.recordHashrateLocked(*info.hashrate, at)
 	}
-	logs := make([]domain.XMRigLogEntry, 0, count)
-	if s.logCount < maxLogs {
-		start := s.logCount - count
-		for i := 0; i < count; i++ {
-			logs = append(logs, s.logs[start+i])
+	if pool := info.poolConnected; pool != "" {
+		s.setPoolConnectedLocked(pool, true, "", at)
+	}
+	if share := info.share; share != nil {
+		s.events.publish(domain.XMRigShareEvent{
+			Time:       at,
+			Accepted:   share.accepted,
+			Stale:      share.stale,
+			Difficulty: share.difficulty,
+			Latency:    share.latency,
+			Reason:     share.reason,
+		})
+		s.accepted = share.acceptedN
+		s.rejected = share.rejectedN
+		if share.stale {
+			s.stale++
+		}
+		if share.accepted {
+			s.recordPoolHealthyLocked()
+		}
+		s.shareLatency = share.latency
+		if share.difficulty > 0 {
+			s.difficulty = share.difficulty
 		}
-		return logs
 	}
-	start := s.logIndex - count
-	if start < 0 {
-		start += maxLogs
+	if job := info.job; job != nil {
+		s.lastJob = at
+		s.difficulty = job.difficulty
+		if job.height > 0 {
+			s.height = job.height
+		}
+		s.pool = job.pool
+		s.recordPoolHealthyLocked()
+		s.setPoolConnectedLocked(job.pool, true, "", at)
+		s.events.publish(domain.XMRigJobEvent{
+			Time:       at,
+			Pool:       job.pool,
+			Difficulty: job.difficulty,
+			Algo:       job.algo,
+			Height:     job.height,
+		})
 	}
-	for i := 0; i < count; i++ {
-		idx := (start + i) % maxLogs
-		logs = append(logs, s.logs[idx])
+	if failure := info.poolError; failure != nil {
+		if generation == s.failover.generation {
+			s.recordPoolFailureLocked(*failure)
+		}
+		s.setPoolConnectedLocked(failure.pool, false, failure.message, at)
+	}
+	if randomx := info.randomx; randomx != nil {
+		s.recordRandomXLocked(*randomx, at)
 	}
-	return logs
 }
 
-func (r *Wrapper) streamLogs(reader io.Reader) {
+func (s *state) recordAPISummary(summary apiSummary, generation uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.running {
+		return
+	}
+	s.source = domain.XMRigSourceAPI
+	var totals [3]*float64
+	copy(totals[:], summary.Hashrate.Total)
+	threads := make([]float64, 0, len(summary.Hashrate.Threads))
+	for _, thread := range summary.Hashrate.Threads {
+		value := 0.0
+		if len(thread) > 0 && thread[0] != nil {
+			value = *thread[0]
+		}
+		threads = append(threads, value)
+	}
+	s.threads = threads
+	s.recordHashrateLocked(hashrateInfo{
+		tenSec:     totals[0],
+		sixtySec:   totals[1],
+		fifteenMin: totals[2],
+		max:        summary.Hashrate.Highest,
+	}, time.Now().UTC())
+	s.pool = summary.Connection.Pool
+	s.uptime = summary.Uptime
+	s.difficulty = summary.Connection.Diff
+	s.accepted = summary.Connection.Accepted
+	s.rejected = summary.Connection.Rejected
+	if generation == s.failover.generation {
+		s.recordAPIPoolFailuresLocked(summary.Connection.Failures)
+	}
+}
+
+func (s *state) recordHashrateLocked(hashrate hashrateInfo, at time.Time) {
+	s.hashrate = hashrate
+	if hashrate.tenSec != nil {
+		s.history.add(hashrateSample{at: at, hashrate: *hashrate.tenSec})
+	}
+	if hashrate.fifteenMin != nil {
+		s.last15m = hashrate.fifteenMin
+		s.last15mAt = at
+	}
+	s.events.publish(domain.XMRigHashrateEvent{
+		Time:    at,
+		Source:  s.source,
+		HS:      copyFloat(hashrate.tenSec),
+		HS60s:   copyFloat(hashrate.sixtySec),
+		HS15m:   copyFloat(hashrate.fifteenMin),
+		HSMax:   copyFloat(hashrate.max),
+		Threads: append([]float64(nil), s.threads...),
+	})
+}
+
+// setPoolConnectedLocked publishes a connect or disconnect event when the
+// connection state changes, and a connect event when xmrig moves to another
+// pool without a disconnect in between.
+func (s *state) setPoolConnectedLocked(pool string, connected bool, message string, at time.Time) {
+	current := ""
+	if connected {
+		current = pool
+	}
+	if s.connectedPool == current {
+		return
+	}
+	s.connectedPool = current
+	s.events.publish(domain.XMRigPoolConnectionEvent{
+		Time:      at,
+		Pool:      pool,
+		Connected: connected,
+		Error:     message,
+	})
+}
+
+func copyFloat(value *float64) *float64 {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
+
+func (s *state) recordAPIFailure() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.source = domain.XMRigSourceLog
+	s.threads = nil
+}
+
+func (s *state) hashrateHistory(query domain.XMRigHashrateQuery) domain.XMRigHashrateHistory {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.history.buckets(query)
+}
+
+func (s *state) queryLogs(query domain.XMRigLogQuery) domain.XMRigLogPage {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.logs.query(query)
+}
+
+func (s *state) closeLogFile() {
+	s.mu.RLock()
+	file := s.file
+	s.mu.RUnlock()
+	if file != nil {
+		file.close()
+	}
+}
+
+func (r *Wrapper) streamLogs(reader io.Reader, stream string, generation uint64) {
 	scanner := bufio.NewScanner(reader)
 	for scanner.Scan() {
 		line := scanner.Text()
 		if r.output != nil {
 			_, _ = fmt.Fprintln(r.output, line)
 		}
-		now := time.Now().UTC()
-		if value, ok := parseHashrateFromLog(line); ok {
-			r.state.recordLine(line, now, true, value)
-		} else {
-			r.state.recordLine(line, now, false, 0)
+		info := parseLine(line)
+		r.state.recordLine(line, stream, time.Now().UTC(), info, generation)
+		if info.poolError != nil {
+			r.checkFailover(generation)
 		}
 	}
 	if err := scanner.Err(); err != nil {
@@ -173,85 +631,239 @@ func (r *Wrapper) streamLogs(reader io.Reader) {
 		observability.CaptureError(err, map[string]string{
 			"component": "xmrig",
 			"operation": "log_scan",
+			"stream":    stream,
 		}, nil)
 	}
 }
 
 func (r *Wrapper) run(ctx context.Context) {
-	xmrigPath, err := exec.LookPath("xmrig")
-	if err != nil {
-		log.Printf("xmrig lookup: %v", err)
-		r.state.recordExit(time.Now().UTC(), err)
-		return
-	}
-
-	args := r.config.Args
-
 	for {
-		if ctx.Err() != nil {
+		if !r.waitForDesiredRunning(ctx) {
 			return
 		}
+		if job := r.pendingTune(); job != nil {
+			r.runTune(ctx, job)
+			continue
+		}
+		if run := r.pendingBenchmark(); run != nil {
+			r.runBenchmark(ctx, run)
+			continue
+		}
 
-		cmd := exec.CommandContext(ctx, xmrigPath, args...)
-		stdout, err := cmd.StdoutPipe()
+		xmrigPath, refused := r.prepareBinary(ctx)
+		if refused != nil {
+			log.Printf("xmrig binary: %v", refused.err)
+			r.state.recordExit(time.Now().UTC(), refused.err, "", exitInfo{})
+			if !r.restartAfterFailure(ctx, refused.operation, refused.err, refused.extra) {
+				return
+			}
+			continue
+		}
+		args, api, err := r.launchArgs()
 		if err != nil {
-			log.Printf("xmrig stdout: %v", err)
-			observability.CaptureError(err, map[string]string{
-				"component": "xmrig",
-				"operation": "stdout_pipe",
-			}, nil)
-			r.state.recordExit(time.Now().UTC(), err)
-			if !sleepWithContext(ctx, r.config.RestartDelay) {
+			log.Printf("xmrig config: %v", err)
+			r.state.recordExit(time.Now().UTC(), err, "", exitInfo{})
+			if !r.restartAfterFailure(ctx, "config", err, nil) {
+				return
+			}
+			continue
+		}
+		cmd := newProcessGroup(xmrigPath, args)
+		pipes, err := attachOutput(cmd)
+		if err != nil {
+			log.Printf("xmrig output pipes: %v", err)
+			r.state.recordExit(time.Now().UTC(), err, "", exitInfo{})
+			if !r.restartAfterFailure(ctx, "output_pipe", err, nil) {
 				return
 			}
 			continue
 		}
 		if err := cmd.Start(); err != nil {
+			pipes.closeWriters()
+			pipes.closeReaders()
 			log.Printf("xmrig start: %v", err)
-			observability.CaptureError(err, map[string]string{
-				"component": "xmrig",
-				"operation": "start",
-			}, nil)
-			r.state.recordExit(time.Now().UTC(), err)
-			if !sleepWithContext(ctx, r.config.RestartDelay) {
+			r.state.recordExit(time.Now().UTC(), err, "", exitInfo{})
+			if !r.restartAfterFailure(ctx, "start", err, nil) {
 				return
 			}
 			continue
 		}
-		r.state.recordStart(time.Now().UTC())
+		pipes.closeWriters()
 
-		r.streamLogs(stdout)
+		procCtx, cancel := context.WithCancel(ctx)
+		exited := make(chan struct{})
+		go terminateOnCancel(procCtx, cmd, r.config.StopGracePeriod, exited)
+		r.setProcess(&process{cmd: cmd, cancel: cancel})
+		generation := r.state.recordStart(time.Now().UTC(), cmd.Process.Pid)
+		r.applyHolds()
+		stable := time.AfterFunc(r.config.StableUptime, func() {
+			r.restarts.reset()
+			r.state.recordStable()
+		})
+		if api != nil {
+			go r.pollAPI(procCtx, api, generation)
+		}
+		go r.sampleResources(procCtx, cmd.Process.Pid)
+
+		var streams sync.WaitGroup
+		streams.Add(2)
+		go func() {
+			defer streams.Done()
+			r.streamLogs(pipes.stdout, domain.XMRigStreamStdout, generation)
+		}()
+		go func() {
+			defer streams.Done()
+			r.streamLogs(pipes.stderr, domain.XMRigStreamStderr, generation)
+		}()
 		waitErr := cmd.Wait()
-		if waitErr != nil && ctx.Err() == nil {
+		close(exited)
+		killProcessGroup(cmd)
+		streams.Wait()
+		pipes.closeReaders()
+		stable.Stop()
+
+		reason := r.takeProcess().reason
+		if ctx.Err() != nil {
+			reason = domain.XMRigStopShutdown
+		}
+		cancel()
+		code, signal := exitDetails(waitErr)
+		exit := exitInfo{code: code, signal: signal}
+		if reason != "" {
+			r.state.recordExit(time.Now().UTC(), nil, reason, exit)
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+		if waitErr != nil {
 			log.Printf("xmrig exited: %v", waitErr)
-			observability.CaptureError(waitErr, map[string]string{
-				"component": "xmrig",
-				"operation": "wait",
-			}, nil)
 		}
-		if ctx.Err() != nil {
-			r.state.recordExit(time.Now().UTC(), nil)
+		r.state.recordExit(time.Now().UTC(), waitErr, domain.XMRigStopExited, exit)
+
+		if !r.restartAfterFailure(ctx, "wait", waitErr, map[string]interface{}{
+			"stderr": r.state.stderrLines(),
+		}) {
 			return
 		}
-		r.state.recordExit(time.Now().UTC(), waitErr)
+	}
+}
 
-		if !sleepWithContext(ctx, r.config.RestartDelay) {
-			return
+// restartAfterFailure reports an unexpected exit and waits out the backoff.
+// While xmrig is crash looping only the transition into the loop is sent to
+// Sentry, so a broken config does not flood it.
+func (r *Wrapper) restartAfterFailure(ctx context.Context, operation string, err error, extra map[string]interface{}) bool {
+	now := time.Now().UTC()
+	delay, looping := r.restarts.recordExit(now)
+	wasLooping := r.state.recordRestartScheduled(delay, looping, now.Add(delay))
+	switch {
+	case looping && !wasLooping:
+		loopErr := fmt.Errorf("xmrig crash loop: %d exits within %s", r.config.CrashLoopExits, r.config.CrashLoopWindow)
+		log.Printf("%v, backing off %s", loopErr, delay)
+		if extra == nil {
+			extra = map[string]interface{}{}
 		}
+		if err != nil {
+			extra["last_error"] = err.Error()
+		}
+		observability.CaptureError(loopErr, map[string]string{
+			"component": "xmrig",
+			"operation": "crash_loop",
+		}, extra)
+	case !looping && err != nil:
+		observability.CaptureError(err, map[string]string{
+			"component": "xmrig",
+			"operation": operation,
+		}, extra)
 	}
+	return r.sleep(ctx, delay)
 }
 
-func normalizeLogCount(count int) int {
-	if count <= 0 {
-		return 0
+// launchArgs renders config.json for this launch and returns the command
+// line pointing at it, followed by the extra and schedule args. A nil client
+// means status comes from log parsing only.
+func (r *Wrapper) launchArgs() ([]string, *apiClient, error) {
+	miner := r.routeThroughProxy(r.minerConfigForLaunch())
+	if err := miner.Validate(); err != nil {
+		return nil, nil, err
 	}
-	if count > maxLogs {
-		return maxLogs
+	endpoint := r.launchEndpoint()
+	data, err := miner.render(endpoint)
+	if err != nil {
+		return nil, nil, err
 	}
-	return count
+	if err := writeMinerConfig(r.config.ConfigPath, data); err != nil {
+		return nil, nil, fmt.Errorf("write %s: %w", r.config.ConfigPath, err)
+	}
+
+	args := []string{"--config=" + r.config.ConfigPath}
+	args = append(args, r.config.ExtraArgs...)
+	args = append(args, r.scheduleLaunchArgs()...)
+	if endpoint == nil {
+		return args, nil, nil
+	}
+	return args, endpoint.client(), nil
+}
+
+// launchEndpoint picks the API port and token for a launch, or nil when the
+// API is disabled or unavailable.
+func (r *Wrapper) launchEndpoint() *apiEndpoint {
+	if r.config.APIPort < 0 {
+		return nil
+	}
+	endpoint, err := newAPIEndpoint(r.config.APIPort)
+	if err != nil {
+		log.Printf("xmrig api endpoint: %v", err)
+		observability.CaptureError(err, map[string]string{
+			"component": "xmrig",
+			"operation": "api_endpoint",
+		}, nil)
+		return nil
+	}
+	return &endpoint
 }
 
-func sleepWithContext(ctx context.Context, d time.Duration) bool {
+func (r *Wrapper) pollAPI(ctx context.Context, client *apiClient, generation uint64) {
+	ticker := time.NewTicker(r.config.APIPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		summary, err := client.summary(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				r.state.recordAPIFailure()
+			}
+			continue
+		}
+		r.state.recordAPISummary(summary, generation)
+		r.checkFailover(generation)
+	}
+}
+
+// waitForDesiredRunning blocks while the operator wants xmrig stopped and
+// no benchmark or auto-tune is queued.
+func (r *Wrapper) waitForDesiredRunning(ctx context.Context) bool {
+	for {
+		if ctx.Err() != nil {
+			return false
+		}
+		if r.state.desiredState() == domain.XMRigDesiredRunning || r.pendingBenchmark() != nil || r.pendingTune() != nil {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-r.wake:
+		}
+	}
+}
+
+// sleep waits for d, returning early when a control action wakes the loop.
+func (r *Wrapper) sleep(ctx context.Context, d time.Duration) bool {
 	timer := time.NewTimer(d)
 	defer timer.Stop()
 	select {
@@ -259,5 +871,17 @@ func sleepWithContext(ctx context.Context, d time.Duration) bool {
 		return false
 	case <-timer.C:
 		return true
+	case <-r.wake:
+		return ctx.Err() == nil
+	}
+}
+
+func (r *Wrapper) normalizeLogCount(count int) int {
+	if count <= 0 {
+		return 0
+	}
+	if count > r.config.LogBufferSize {
+		return r.config.LogBufferSize
 	}
+	return count
 }
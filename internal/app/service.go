@@ -12,23 +12,77 @@ type Service struct {
 	specsReader   ports.SpecsReader
 	metricsReader ports.MetricsReader
 	xmrigMonitor  ports.XMRigMonitor
+	xmrigControl  ports.XMRigController
 }
 
-func NewService(specsReader ports.SpecsReader, metricsReader ports.MetricsReader, xmrigMonitor ports.XMRigMonitor) *Service {
+func NewService(specsReader ports.SpecsReader, metricsReader ports.MetricsReader, xmrigMonitor ports.XMRigMonitor, xmrigControl ports.XMRigController) *Service {
 	return &Service{
 		specsReader:   specsReader,
 		metricsReader: metricsReader,
 		xmrigMonitor:  xmrigMonitor,
+		xmrigControl:  xmrigControl,
 	}
 }
 
 func (s *Service) Health() domain.Health {
 	return domain.Health{
-		Status: "ok",
+		Status: domain.HealthOK,
 		Time:   time.Now().UTC(),
 	}
 }
 
+// DetailedHealth adds per-component checks; the node is degraded when any
+// of them fails.
+func (s *Service) DetailedHealth() domain.Health {
+	health := s.Health()
+	if s.xmrigMonitor == nil {
+		return health
+	}
+	status := s.xmrigMonitor.Status()
+	health.PoolProbe = status.PoolProbe
+	health.Checks = []domain.HealthCheck{xmrigCheck(status), poolCheck(status.PoolProbe)}
+	for _, check := range health.Checks {
+		if check.Status != domain.HealthOK {
+			health.Status = domain.HealthDegraded
+		}
+	}
+	return health
+}
+
+func xmrigCheck(status domain.XMRigStatus) domain.HealthCheck {
+	check := domain.HealthCheck{Name: "xmrig", Status: domain.HealthOK}
+	switch {
+	case status.CrashLooping:
+		check.Status = domain.HealthDegraded
+		check.Message = "crash looping"
+	case status.Benchmark != nil:
+		check.Message = "benchmarking"
+	case status.DesiredState == domain.XMRigDesiredRunning && !status.Running:
+		check.Status = domain.HealthDegraded
+		check.Message = "not running"
+	default:
+		check.Message = status.DesiredState
+	}
+	if check.Status != domain.HealthOK && status.LastError != "" {
+		check.Message += ": " + status.LastError
+	}
+	return check
+}
+
+func poolCheck(probe *domain.XMRigPoolProbe) domain.HealthCheck {
+	check := domain.HealthCheck{Name: "pool", Status: domain.HealthOK}
+	switch {
+	case probe == nil:
+		check.Message = "not probed yet"
+	case !probe.OK:
+		check.Status = domain.HealthDegraded
+		check.Message = probe.Pool + ": " + probe.Stage + ": " + probe.Error
+	default:
+		check.Message = probe.Pool + " reachable"
+	}
+	return check
+}
+
 func (s *Service) Specs(ctx context.Context) (domain.Specs, error) {
 	return s.specsReader.ReadSpecs(ctx)
 }
@@ -44,9 +98,120 @@ func (s *Service) XMRigStatus() domain.XMRigStatus {
 	return s.xmrigMonitor.Status()
 }
 
-func (s *Service) XMRigLogs(n int) []domain.XMRigLogEntry {
+func (s *Service) XMRigLogs(query domain.XMRigLogQuery) (domain.XMRigLogPage, error) {
+	if s.xmrigMonitor == nil {
+		return domain.XMRigLogPage{Logs: []domain.XMRigLogEntry{}}, nil
+	}
+	return s.xmrigMonitor.Logs(query)
+}
+
+func (s *Service) XMRigLogStream(backlog int) ([]domain.XMRigLogEntry, <-chan domain.XMRigEvent, func()) {
+	if s.xmrigMonitor == nil {
+		closed := make(chan domain.XMRigEvent)
+		close(closed)
+		return []domain.XMRigLogEntry{}, closed, func() {}
+	}
+	return s.xmrigMonitor.SubscribeLogs(backlog)
+}
+
+// XMRigEvents subscribes to wrapper events of the given types, or all types.
+func (s *Service) XMRigEvents(types ...string) (<-chan domain.XMRigEvent, func()) {
+	if s.xmrigMonitor == nil {
+		closed := make(chan domain.XMRigEvent)
+		close(closed)
+		return closed, func() {}
+	}
+	return s.xmrigMonitor.SubscribeEvents(types...)
+}
+
+func (s *Service) XMRigHashrateHistory(query domain.XMRigHashrateQuery) domain.XMRigHashrateHistory {
+	if s.xmrigMonitor == nil {
+		return domain.XMRigHashrateHistory{
+			From:    query.From,
+			To:      query.To,
+			Step:    query.Step,
+			Buckets: []domain.XMRigHashrateBucket{},
+		}
+	}
+	return s.xmrigMonitor.HashrateHistory(query)
+}
+
+func (s *Service) XMRigResourceHistory(query domain.XMRigResourceQuery) domain.XMRigResourceHistory {
+	if s.xmrigMonitor == nil {
+		return domain.XMRigResourceHistory{Samples: []domain.XMRigResources{}}
+	}
+	return s.xmrigMonitor.ResourceHistory(query)
+}
+
+func (s *Service) XMRigSchedule() domain.XMRigSchedule {
+	if s.xmrigMonitor == nil {
+		return domain.XMRigSchedule{Windows: []domain.XMRigScheduleWindow{}}
+	}
+	return s.xmrigMonitor.Schedule()
+}
+
+func (s *Service) StartXMRig() error {
+	if s.xmrigControl == nil {
+		return domain.ErrXMRigUnavailable
+	}
+	return s.xmrigControl.StartMining()
+}
+
+func (s *Service) StopXMRig() error {
+	if s.xmrigControl == nil {
+		return domain.ErrXMRigUnavailable
+	}
+	return s.xmrigControl.StopMining()
+}
+
+func (s *Service) RestartXMRig() error {
+	if s.xmrigControl == nil {
+		return domain.ErrXMRigUnavailable
+	}
+	return s.xmrigControl.RestartMining()
+}
+
+func (s *Service) PauseXMRig() error {
+	if s.xmrigControl == nil {
+		return domain.ErrXMRigUnavailable
+	}
+	return s.xmrigControl.PauseMining()
+}
+
+// BenchmarkXMRig queues a benchmark; the node's specs are stored with the
+// result when they can be read.
+func (s *Service) BenchmarkXMRig(ctx context.Context, req domain.XMRigBenchmarkRequest) (domain.XMRigBenchmark, error) {
+	if s.xmrigControl == nil {
+		return domain.XMRigBenchmark{}, domain.ErrXMRigUnavailable
+	}
+	if specs, err := s.specsReader.ReadSpecs(ctx); err == nil {
+		req.Specs = &specs
+	}
+	return s.xmrigControl.Benchmark(req)
+}
+
+func (s *Service) XMRigBenchmarks(limit int) []domain.XMRigBenchmark {
+	if s.xmrigMonitor == nil {
+		return []domain.XMRigBenchmark{}
+	}
+	return s.xmrigMonitor.Benchmarks(limit)
+}
+
+// TuneXMRig queues an auto-tune sweep; the node's specs are stored with each
+// of its benchmarks.
+func (s *Service) TuneXMRig(ctx context.Context, req domain.XMRigTuneRequest) (domain.XMRigTune, error) {
+	if s.xmrigControl == nil {
+		return domain.XMRigTune{}, domain.ErrXMRigUnavailable
+	}
+	if specs, err := s.specsReader.ReadSpecs(ctx); err == nil {
+		req.Specs = &specs
+	}
+	return s.xmrigControl.Tune(req)
+}
+
+func (s *Service) XMRigTuning() domain.XMRigTuneStatus {
 	if s.xmrigMonitor == nil {
-		return []domain.XMRigLogEntry{}
+		return domain.XMRigTuneStatus{}
 	}
-	return s.xmrigMonitor.Logs(n)
+	return s.xmrigMonitor.Tuning()
 }
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	XMRigBenchmark1M  = "1M"
+	XMRigBenchmark10M = "10M"
+)
+
+const (
+	XMRigBenchmarkPending = "pending"
+	XMRigBenchmarkRunning = "running"
+	XMRigBenchmarkDone    = "done"
+	XMRigBenchmarkFailed  = "failed"
+	// XMRigBenchmarkInvalid is a run refused or cut short because a thermal
+	// or idle hold or a thermal throttle would have skewed its result.
+	XMRigBenchmarkInvalid = "invalid"
+)
+
+var (
+	ErrXMRigBenchmarkRunning       = errors.New("an xmrig benchmark is already pending or running")
+	ErrXMRigInvalidBenchmarkSize   = errors.New("benchmark size must be 1M or 10M")
+	ErrXMRigInvalidBenchmarkConfig = errors.New("invalid benchmark config")
+)
+
+// XMRigMinerOverrides are proposed changes to the configured CPU and RandomX
+// settings. Nil fields keep the configured value.
+type XMRigMinerOverrides struct {
+	Threads      *int
+	Affinity     []int
+	Priority     *int
+	HugePages    *bool
+	HugePagesJIT *bool
+	Yield        *bool
+	RandomXMode  *string
+	OneGBPages   *bool
+	InitThreads  *int
+	WrMSR        *bool
+}
+
+// XMRigBenchmarkRequest runs the current config, or the current config with
+// Overrides applied. Specs are stored with the result.
+type XMRigBenchmarkRequest struct {
+	Size      string
+	Overrides *XMRigMinerOverrides
+	Specs     *Specs
+}
+
+// XMRigBenchmarkConfig is the effective config a benchmark ran with.
+// Threads is 0 when xmrig picked the thread count itself.
+type XMRigBenchmarkConfig struct {
+	Threads      int
+	Affinity     []int
+	Priority     int
+	HugePages    bool
+	HugePagesJIT bool
+	Yield        bool
+	RandomXMode  string
+	OneGBPages   bool
+	InitThreads  int
+	WrMSR        bool
+	// Args are the extra xmrig args, only applied to the current config.
+	Args []string
+}
+
+// XMRigBenchmark is one xmrig --bench run. Result fields are nil until the
+// run is done.
+type XMRigBenchmark struct {
+	ID        string
+	Size      string
+	Status    string
+	Proposed  bool
+	StartTime time.Time
+	EndTime   *time.Time
+	Algo      string
+	// Seconds and HashrateHS are as reported by xmrig for the whole run.
+	Seconds    *float64
+	HashrateHS *float64
+	HashSum    string
+	Config     XMRigBenchmarkConfig
+	Specs      *Specs
+	TempStartC *float64
+	TempMaxC   *float64
+	TempEndC   *float64
+	// PowerW is the average CPU package power sampled during the run.
+	PowerW *float64
+	Error  string
+}
@@ -0,0 +1,109 @@
+package domain
+
+import "time"
+
+const (
+	XMRigEventStarted          = "started"
+	XMRigEventExited           = "exited"
+	XMRigEventHashrate         = "hashrate"
+	XMRigEventShareAccepted    = "share_accepted"
+	XMRigEventShareRejected    = "share_rejected"
+	XMRigEventPoolConnected    = "pool_connected"
+	XMRigEventPoolDisconnected = "pool_disconnected"
+	XMRigEventJob              = "job"
+	XMRigEventPoolSwitched     = "pool_switched"
+	XMRigEventLog              = "log"
+)
+
+// XMRigEvent is implemented by every event the xmrig wrapper publishes.
+// Subscribers switch on the concrete type.
+type XMRigEvent interface {
+	EventType() string
+	EventTime() time.Time
+}
+
+type XMRigStartedEvent struct {
+	Time time.Time
+	PID  int
+}
+
+// XMRigExitedEvent carries either Code or Signal. Reason is one of the
+// XMRigStop values; Error is empty for a clean exit.
+type XMRigExitedEvent struct {
+	Time   time.Time
+	Reason string
+	Code   *int
+	Signal string
+	Error  string
+}
+
+type XMRigHashrateEvent struct {
+	Time    time.Time
+	Source  string
+	HS      *float64
+	HS60s   *float64
+	HS15m   *float64
+	HSMax   *float64
+	Threads []float64
+}
+
+// XMRigShareEvent is a share result; Accepted selects the event type.
+type XMRigShareEvent struct {
+	Time       time.Time
+	Accepted   bool
+	Stale      bool
+	Difficulty uint64
+	Latency    time.Duration
+	Reason     string
+}
+
+// XMRigPoolConnectionEvent reports xmrig connecting to or losing a pool.
+type XMRigPoolConnectionEvent struct {
+	Time      time.Time
+	Pool      string
+	Connected bool
+	Error     string
+}
+
+// XMRigLogEvent carries a line xmrig wrote to stdout or stderr.
+type XMRigLogEvent struct {
+	Entry XMRigLogEntry
+}
+
+type XMRigJobEvent struct {
+	Time       time.Time
+	Pool       string
+	Difficulty uint64
+	Algo       string
+	Height     uint64
+}
+
+func (e XMRigStartedEvent) EventType() string  { return XMRigEventStarted }
+func (e XMRigExitedEvent) EventType() string   { return XMRigEventExited }
+func (e XMRigHashrateEvent) EventType() string { return XMRigEventHashrate }
+func (e XMRigJobEvent) EventType() string      { return XMRigEventJob }
+func (e XMRigPoolEvent) EventType() string     { return XMRigEventPoolSwitched }
+func (e XMRigLogEvent) EventType() string      { return XMRigEventLog }
+
+func (e XMRigShareEvent) EventType() string {
+	if e.Accepted {
+		return XMRigEventShareAccepted
+	}
+	return XMRigEventShareRejected
+}
+
+func (e XMRigPoolConnectionEvent) EventType() string {
+	if e.Connected {
+		return XMRigEventPoolConnected
+	}
+	return XMRigEventPoolDisconnected
+}
+
+func (e XMRigStartedEvent) EventTime() time.Time        { return e.Time }
+func (e XMRigExitedEvent) EventTime() time.Time         { return e.Time }
+func (e XMRigHashrateEvent) EventTime() time.Time       { return e.Time }
+func (e XMRigShareEvent) EventTime() time.Time          { return e.Time }
+func (e XMRigPoolConnectionEvent) EventTime() time.Time { return e.Time }
+func (e XMRigJobEvent) EventTime() time.Time            { return e.Time }
+func (e XMRigPoolEvent) EventTime() time.Time           { return e.Time }
+func (e XMRigLogEvent) EventTime() time.Time            { return e.Entry.Time }
@@ -1,10 +1,81 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+const (
+	XMRigDesiredRunning = "running"
+	XMRigDesiredPaused  = "paused"
+	XMRigDesiredStopped = "stopped"
+)
+
+const (
+	XMRigStopOperator = "operator_stop"
+	XMRigStopRestart  = "operator_restart"
+	XMRigStopShutdown = "shutdown"
+	XMRigStopExited   = "exited"
+	XMRigStopThermal  = "thermal"
+	XMRigStopSchedule = "schedule"
+	XMRigStopFailover = "failover"
+	// XMRigStopBenchmark stops regular mining for a benchmark run.
+	XMRigStopBenchmark = "benchmark"
+	// XMRigStopTune stops regular mining for an auto-tune sweep.
+	XMRigStopTune = "tune"
+)
+
+// Pool probe stages, the step a failed probe stopped at.
+const (
+	XMRigProbeStageDNS     = "dns"
+	XMRigProbeStageConnect = "connect"
+	XMRigProbeStageTLS     = "tls"
+)
+
+const (
+	XMRigModeAlways = "always"
+	XMRigModeIdle   = "idle"
+)
+
+const (
+	XMRigThermalPause    = "pause"
+	XMRigThermalThrottle = "throttle"
+	XMRigThermalResume   = "resume"
+)
+
+const (
+	XMRigStreamStdout = "stdout"
+	XMRigStreamStderr = "stderr"
+)
+
+const (
+	XMRigSourceAPI = "api"
+	XMRigSourceLog = "log"
+)
+
+var (
+	ErrXMRigUnavailable = errors.New("xmrig control is unavailable")
+	ErrXMRigNotRunning  = errors.New("xmrig is not running")
+	ErrXMRigNoLogFile   = errors.New("xmrig log file is not configured")
+)
+
+const (
+	HealthOK       = "ok"
+	HealthDegraded = "degraded"
+)
 
 type Health struct {
 	Status string
 	Time   time.Time
+	// Checks and PoolProbe are only filled in for a detailed check.
+	Checks    []HealthCheck
+	PoolProbe *XMRigPoolProbe
+}
+
+type HealthCheck struct {
+	Name    string
+	Status  string
+	Message string
 }
 
 type Specs struct {
@@ -26,14 +97,311 @@ type Metrics struct {
 
 type XMRigStatus struct {
 	Running       bool
+	Paused        bool
+	DesiredState  string
+	StatusSource  string
 	HashrateHS    float64
-	LastLogTime   *time.Time
-	LastStartTime *time.Time
-	LastExitTime  *time.Time
+	Hashrate60sHS *float64
+	Hashrate15mHS *float64
+	HashrateMaxHS *float64
+	// LastHashrate15mHS is the most recent 15m average, kept after xmrig exits.
+	LastHashrate15mHS   *float64
+	LastHashrate15mTime *time.Time
+	ThreadsHS           []float64
+	Pool                string
+	UptimeSeconds       int64
+	Difficulty          uint64
+	SharesAccepted      uint64
+	SharesRejected      uint64
+	SharesStale         uint64
+	BlockHeight         uint64
+	LastJobTime         *time.Time
+	ShareLatencyMS      int64
+	RandomXMode         string
+	RestartCount        int
+	BackoffMS           int64
+	CrashLooping        bool
+	NextRestartTime     *time.Time
+	StopReason          string
+	ExitCode            *int
+	ExitSignal          string
+	LastLogTime         *time.Time
+	LastStartTime       *time.Time
+	LastExitTime        *time.Time
+	LastError           string
+	// Thermal is nil when the thermal guard is disabled.
+	Thermal *XMRigThermalStatus
+	// MiningMode is XMRigModeAlways or XMRigModeIdle; Idle is only set in
+	// idle mode.
+	MiningMode string
+	Idle       *XMRigIdleStatus
+	// Failover is nil unless more than one pool is configured.
+	Failover *XMRigFailoverStatus
+	// Proxy is nil unless xmrig mines through the local stratum proxy.
+	Proxy *XMRigProxyStatus
+	// PoolProbe is nil until the first probe finished or when probing is off.
+	PoolProbe *XMRigPoolProbe
+	// Binary is nil until the xmrig executable was found and probed.
+	Binary *XMRigBinary
+	// Resources is the latest /proc sample, nil while xmrig is not running.
+	Resources *XMRigResources
+	// RandomX is nil until xmrig logged its first RandomX startup line.
+	RandomX *XMRigRandomX
+	// Benchmark is the pending or running benchmark, if any.
+	Benchmark *XMRigBenchmark
+	// TuneProfile is the applied auto-tune profile, if any.
+	TuneProfile *XMRigTuneProfile
+}
+
+const (
+	XMRigMSROK     = "ok"
+	XMRigMSRFailed = "failed"
+)
+
+// XMRigRandomX is RandomX readiness parsed from xmrig's startup lines for
+// the running process. Strings are empty until xmrig logged them.
+type XMRigRandomX struct {
+	Mode string
+	// HugePages and OneGBPages are xmrig's banner values: supported,
+	// unavailable, disabled or permission granted.
+	HugePages  string
+	OneGBPages string
+	// HugePagesPercent is the share of dataset and cache pages backed by
+	// huge pages.
+	HugePagesPercent   *float64
+	HugePagesAllocated int
+	HugePagesTotal     int
+	DatasetMB          int
+	// MSR is XMRigMSROK, XMRigMSRFailed or empty when xmrig did not try.
+	MSR              string
+	MSRPreset        string
+	MSRError         string
+	Algo             string
+	Initializing     bool
+	DatasetReady     bool
+	DatasetInitMS    *int64
+	DatasetReadyTime *time.Time
+	// Degraded is set when huge pages or the MSR mod failed, which costs a
+	// large part of the hashrate.
+	Degraded        bool
+	DegradedReasons []string
+}
+
+// XMRigResources is one sample of the xmrig process from /proc. CPUPercent
+// is 100 per fully used core and nil for the first sample of a process; the
+// I/O counters are nil when /proc/<pid>/io is unreadable.
+type XMRigResources struct {
+	Time                   time.Time
+	PID                    int
+	CPUPercent             *float64
+	UserSeconds            float64
+	SystemSeconds          float64
+	RSSBytes               uint64
+	PeakRSSBytes           uint64
+	Threads                int
+	VoluntaryCtxSwitches   uint64
+	InvoluntaryCtxSwitches uint64
+	Nice                   int
+	ReadBytes              *uint64
+	WriteBytes             *uint64
+}
+
+// XMRigResourceQuery selects samples after Since, keeping the newest Limit.
+type XMRigResourceQuery struct {
+	Since time.Time
+	Limit int
+}
+
+type XMRigResourceHistory struct {
+	Interval time.Duration
+	Samples  []XMRigResources
+}
+
+// XMRigBinary is the xmrig executable as reported by xmrig --version.
+type XMRigBinary struct {
+	Path     string
+	Version  string
+	Compiler string
+	BuiltOn  string
+	// Features are the CPU features xmrig was built for, like AVX2 or AES.
+	Features []string
+	LibUV    string
+	// TLSVersion and HWLocVersion name the linked library, like OpenSSL/3.0.2,
+	// and are empty when xmrig was built without it.
+	TLS          bool
+	TLSVersion   string
+	HWLoc        bool
+	HWLocVersion string
+	MinVersion   string
+	// SHA256 is the hash taken before the last launch, only when allowed
+	// hashes are configured; Pinned is true when it matched one of them.
+	SHA256     string
+	Pinned     bool
+	DetectedAt time.Time
+}
+
+// XMRigPoolProbe is the result of resolving and dialing the active pool.
+// Durations are in milliseconds and nil for steps that did not run.
+type XMRigPoolProbe struct {
+	Time      time.Time
+	Pool      string
+	TLS       bool
+	Addresses []string
+	DNSMS     *float64
+	ConnectMS *float64
+	TLSMS     *float64
+	OK        bool
+	// Stage and Error describe this probe's failure.
+	Stage               string
+	Error               string
+	ConsecutiveFailures int
+	LastSuccessTime     *time.Time
+	// LastError is the most recent failure, kept after the pool recovers.
 	LastError     string
+	LastErrorTime *time.Time
+}
+
+// XMRigProxyStatus is counted by the stratum proxy from the protocol itself.
+type XMRigProxyStatus struct {
+	Address           string
+	Upstream          string
+	UpstreamConnected bool
+	Miners            int
+	Reconnects        uint64
+	Jobs              uint64
+	LastJobTime       *time.Time
+	SharesSubmitted   uint64
+	SharesAccepted    uint64
+	SharesRejected    uint64
+	LastError         string
+	LastErrorTime     *time.Time
+}
+
+type XMRigFailoverStatus struct {
+	ActivePool  string
+	ActiveIndex int
+	ActiveSince *time.Time
+	Pools       []string
+	Threshold   int
+	// Failures counts consecutive failures of the active pool.
+	Failures            int
+	LastError           string
+	RetryPrimarySeconds int64
+	NextPrimaryRetry    *time.Time
+	Switches            int
+	Events              []XMRigPoolEvent
+}
+
+// XMRigPoolEvent is one pool switch, after failures or to retry the primary.
+type XMRigPoolEvent struct {
+	Time     time.Time
+	From     string
+	To       string
+	Reason   string
+	Failures int
+	Error    string
+}
+
+type XMRigIdleStatus struct {
+	ThresholdPercent float64
+	BusySeconds      int64
+	IdleSeconds      int64
+	// LoadPercent is the share of all CPUs used by processes other than xmrig.
+	LoadPercent *float64
+	LoadTime    *time.Time
+	Holding     bool
+	HeldSince   *time.Time
+	Pauses      int
+}
+
+type XMRigThermalStatus struct {
+	Action          string
+	TripC           float64
+	ResumeC         float64
+	MinHoldSeconds  int64
+	ThrottleThreads int
+	TempC           *float64
+	TempTime        *time.Time
+	Tripped         bool
+	TrippedAt       *time.Time
+	Interventions   int
+	Events          []XMRigThermalEvent
+}
+
+// XMRigThermalEvent is one intervention: a pause, throttle or resume.
+type XMRigThermalEvent struct {
+	Time    time.Time
+	Action  string
+	TempC   float64
+	Threads int
 }
 
 type XMRigLogEntry struct {
-	Time time.Time
-	Line string
+	Seq    uint64
+	Time   time.Time
+	Stream string
+	Line   string
+}
+
+type XMRigLogQuery struct {
+	Limit    int
+	SinceSeq uint64
+	Since    *time.Time
+	Until    *time.Time
+	// History reads the on-disk log, including rotated segments, instead of
+	// the in-memory buffer.
+	History bool
+}
+
+type XMRigLogPage struct {
+	Logs      []XMRigLogEntry
+	OldestSeq uint64
+	NextSeq   uint64
+	HasMore   bool
+}
+
+type XMRigHashrateQuery struct {
+	From time.Time
+	To   time.Time
+	Step time.Duration
+}
+
+type XMRigHashrateBucket struct {
+	Start   time.Time
+	Samples int
+	MinHS   float64
+	MaxHS   float64
+	AvgHS   float64
+}
+
+type XMRigHashrateHistory struct {
+	From    time.Time
+	To      time.Time
+	Step    time.Duration
+	Buckets []XMRigHashrateBucket
+}
+
+// XMRigSchedule describes the mining schedule; Enabled is false when none is
+// configured.
+type XMRigSchedule struct {
+	Enabled        bool
+	Timezone       string
+	Mining         bool
+	Args           []string
+	ActiveWindow   string
+	ActiveSince    *time.Time
+	ActiveUntil    *time.Time
+	NextTransition *time.Time
+	NextWindow     string
+	NextMining     bool
+	LastTransition *time.Time
+	Windows        []XMRigScheduleWindow
+}
+
+type XMRigScheduleWindow struct {
+	Name            string
+	Spec            string
+	DurationSeconds int64
+	Mine            bool
+	Args            []string
 }
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	XMRigTuneHashrate        = "hashrate"
+	XMRigTuneHashratePerWatt = "hashrate_per_watt"
+)
+
+// Tune job statuses. A job is done once its winner was applied and failed
+// when it was stopped, timed out or no candidate qualified.
+const (
+	XMRigTunePending = "pending"
+	XMRigTuneRunning = "running"
+	XMRigTuneDone    = "done"
+	XMRigTuneFailed  = "failed"
+)
+
+// Tune stages, run in this order. Each stage starts from the best candidate
+// so far.
+const (
+	XMRigTuneStageThreads   = "threads"
+	XMRigTuneStageMode      = "randomx_mode"
+	XMRigTuneStageHugePages = "huge_pages"
+)
+
+var (
+	ErrXMRigTuneRunning  = errors.New("xmrig auto-tune is running")
+	ErrXMRigInvalidTune  = errors.New("invalid auto-tune request")
+	ErrXMRigTuneNoWinner = errors.New("no auto-tune candidate qualified")
+)
+
+// XMRigTuneRequest sweeps thread counts with their affinity layouts, then
+// RandomX modes, then huge page options. Empty lists are derived from the
+// CPU topology and the supported modes.
+type XMRigTuneRequest struct {
+	Objective    string
+	Size         string
+	MaxTempC     *float64
+	Threads      []int
+	RandomXModes []string
+	Specs        *Specs
+}
+
+// XMRigCPUTopology summarises the CPUs the sweep was planned from.
+type XMRigCPUTopology struct {
+	CPUs     int
+	Cores    int
+	Packages int
+	L3Bytes  int64
+}
+
+// XMRigTuneCandidate is one benchmarked setting. Eligible candidates
+// finished under the temperature ceiling and, for per-watt tuning, had a
+// power reading. Skipped candidates were stopped by a thermal or idle hold
+// and say nothing about the setting.
+type XMRigTuneCandidate struct {
+	Stage       string
+	Name        string
+	BenchmarkID string
+	Config      XMRigBenchmarkConfig
+	HashrateHS  *float64
+	PowerW      *float64
+	// HashesPerJoule is HashrateHS divided by PowerW.
+	HashesPerJoule *float64
+	TempMaxC       *float64
+	Eligible       bool
+	Skipped        bool
+	Reason         string
+}
+
+// XMRigTuneProfile is the winning setting, layered over the configured miner
+// settings on every launch.
+type XMRigTuneProfile struct {
+	Threads     int
+	Affinity    []int
+	RandomXMode string
+	HugePages   bool
+	OneGBPages  bool
+	Objective   string
+	// Score is HashrateHS or HashesPerJoule depending on Objective.
+	Score       float64
+	HashrateHS  float64
+	PowerW      *float64
+	TempMaxC    *float64
+	BenchmarkID string
+	TunedAt     time.Time
+}
+
+// XMRigTune is an auto-tune job.
+type XMRigTune struct {
+	ID         string
+	Status     string
+	Objective  string
+	Size       string
+	MaxTempC   *float64
+	Stage      string
+	StartTime  time.Time
+	EndTime    *time.Time
+	Topology   XMRigCPUTopology
+	Candidates []XMRigTuneCandidate
+	Profile    *XMRigTuneProfile
+	Error      string
+}
+
+// XMRigTuneStatus is the latest job and the profile currently applied.
+type XMRigTuneStatus struct {
+	Job     *XMRigTune
+	Profile *XMRigTuneProfile
+}
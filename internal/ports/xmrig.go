@@ -4,5 +4,21 @@ import "github.com/restartfu/grid-node/internal/domain"
 
 type XMRigMonitor interface {
 	Status() domain.XMRigStatus
-	Logs(n int) []domain.XMRigLogEntry
+	Logs(query domain.XMRigLogQuery) (domain.XMRigLogPage, error)
+	SubscribeLogs(backlog int) ([]domain.XMRigLogEntry, <-chan domain.XMRigEvent, func())
+	HashrateHistory(query domain.XMRigHashrateQuery) domain.XMRigHashrateHistory
+	ResourceHistory(query domain.XMRigResourceQuery) domain.XMRigResourceHistory
+	Schedule() domain.XMRigSchedule
+	SubscribeEvents(types ...string) (<-chan domain.XMRigEvent, func())
+	Benchmarks(limit int) []domain.XMRigBenchmark
+	Tuning() domain.XMRigTuneStatus
+}
+
+type XMRigController interface {
+	StartMining() error
+	StopMining() error
+	RestartMining() error
+	PauseMining() error
+	Benchmark(req domain.XMRigBenchmarkRequest) (domain.XMRigBenchmark, error)
+	Tune(req domain.XMRigTuneRequest) (domain.XMRigTune, error)
 }
@@ -0,0 +1,209 @@
+package schedule
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxCronSearch bounds how far ahead the next cron match is searched.
+const maxCronSearch = 5 * 366 * 24 * time.Hour
+
+var dayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+// cronSpec is a five-field cron expression: minute hour day-of-month month
+// day-of-week. As in cron, a day matches if either day field matches when
+// both are restricted.
+type cronSpec struct {
+	minute  uint64
+	hour    uint64
+	dom     uint64
+	month   uint64
+	dow     uint64
+	domStar bool
+	dowStar bool
+}
+
+func parseCron(expr string) (cronSpec, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return cronSpec{}, fmt.Errorf("expected 5 fields, got %d", len(fields))
+	}
+	var spec cronSpec
+	var err error
+	if spec.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return cronSpec{}, fmt.Errorf("minute: %w", err)
+	}
+	if spec.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return cronSpec{}, fmt.Errorf("hour: %w", err)
+	}
+	if spec.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return cronSpec{}, fmt.Errorf("day of month: %w", err)
+	}
+	if spec.month, err = parseCronField(fields[3], 1, 12, monthNames); err != nil {
+		return cronSpec{}, fmt.Errorf("month: %w", err)
+	}
+	if spec.dow, err = parseCronField(fields[4], 0, 7, dayNames); err != nil {
+		return cronSpec{}, fmt.Errorf("day of week: %w", err)
+	}
+	if spec.dow&(1<<7) != 0 {
+		spec.dow |= 1
+	}
+	spec.domStar = fields[2] == "*"
+	spec.dowStar = fields[4] == "*"
+	return spec, nil
+}
+
+// parseCronField parses lists of values, ranges and steps such as
+// "1-5", "*/15" or "mon,wed,fri" into a bit set.
+func parseCronField(field string, min, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if base, rawStep, ok := strings.Cut(part, "/"); ok {
+			value, err := strconv.Atoi(rawStep)
+			if err != nil || value <= 0 {
+				return 0, fmt.Errorf("invalid step %q", rawStep)
+			}
+			part, step = base, value
+		}
+		lo, hi := min, max
+		if part != "*" {
+			rawLo, rawHi, isRange := strings.Cut(part, "-")
+			var err error
+			if lo, err = parseCronValue(rawLo, names); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if isRange {
+				if hi, err = parseCronValue(rawHi, names); err != nil {
+					return 0, err
+				}
+			} else if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("%q is outside %d-%d", part, min, max)
+		}
+		for value := lo; value <= hi; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(raw string, names map[string]int) (int, error) {
+	if value, ok := names[strings.ToLower(raw)]; ok {
+		return value, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", raw)
+	}
+	return value, nil
+}
+
+func (c cronSpec) matchesDay(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case c.domStar && c.dowStar:
+		return true
+	case c.domStar:
+		return dow
+	case c.dowStar:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+func (c cronSpec) matches(t time.Time) bool {
+	return c.minute&(1<<uint(t.Minute())) != 0 &&
+		c.hour&(1<<uint(t.Hour())) != 0 &&
+		c.month&(1<<uint(t.Month())) != 0 &&
+		c.matchesDay(t)
+}
+
+// nextMinute returns the first minute in the set at or after minute, or -1.
+func (c cronSpec) nextMinute(minute int) int {
+	rest := c.minute >> uint(minute) << uint(minute)
+	if rest == 0 {
+		return -1
+	}
+	return bits.TrailingZeros64(rest)
+}
+
+// prevMinute returns the last minute in the set at or before minute, or -1.
+func (c cronSpec) prevMinute(minute int) int {
+	rest := c.minute & (1<<uint(minute+1) - 1)
+	if rest == 0 {
+		return -1
+	}
+	return bits.Len64(rest) - 1
+}
+
+// next returns the first matching minute strictly after t, in t's location.
+// Months, days and hours that cannot match are skipped whole, and minutes
+// are looked up in the set, so a match a year away costs a few hundred steps.
+func (c cronSpec) next(t time.Time) (time.Time, bool) {
+	limit := t.Add(maxCronSearch)
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	for t.Before(limit) {
+		switch {
+		case c.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !c.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+		default:
+			minute := c.nextMinute(t.Minute())
+			if minute < 0 {
+				t = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+				continue
+			}
+			return t.Add(time.Duration(minute-t.Minute()) * time.Minute), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// last returns the latest matching minute at or before t, looking back no
+// further than window. It walks the fields backwards the way next walks them
+// forwards.
+func (c cronSpec) last(t time.Time, window time.Duration) (time.Time, bool) {
+	earliest := t.Add(-window)
+	for t = t.Truncate(time.Minute); t.After(earliest); {
+		switch {
+		case c.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()).Add(-time.Minute)
+		case !c.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).Add(-time.Minute)
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = t.Add(-time.Duration(t.Minute()+1) * time.Minute)
+		default:
+			minute := c.prevMinute(t.Minute())
+			if minute < 0 {
+				t = t.Add(-time.Duration(t.Minute()+1) * time.Minute)
+				continue
+			}
+			t = t.Add(-time.Duration(t.Minute()-minute) * time.Minute)
+			if !t.After(earliest) {
+				return time.Time{}, false
+			}
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
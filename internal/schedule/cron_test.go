@@ -0,0 +1,77 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+// scanNext and scanLast are the minute-by-minute definitions next and last
+// must agree with.
+func scanNext(c cronSpec, t time.Time, limit time.Duration) (time.Time, bool) {
+	end := t.Add(limit)
+	for t = t.Truncate(time.Minute).Add(time.Minute); t.Before(end); t = t.Add(time.Minute) {
+		if c.matches(t) {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func scanLast(c cronSpec, t time.Time, window time.Duration) (time.Time, bool) {
+	earliest := t.Add(-window)
+	for t = t.Truncate(time.Minute); t.After(earliest); t = t.Add(-time.Minute) {
+		if c.matches(t) {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func TestCronNextAndLastMatchScan(t *testing.T) {
+	exprs := []string{
+		"0 22 * * mon-fri",
+		"30 2 * * *",
+		"*/20 1-3 * * *",
+		"15 0 1 * *",
+		"0 12 * mar,nov sun",
+		"45 23 31 * *",
+	}
+	zones := []string{"UTC", "America/New_York", "Europe/London", "Australia/Lord_Howe"}
+	for _, zone := range zones {
+		location, err := time.LoadLocation(zone)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, expr := range exprs {
+			spec, err := parseCron(expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Around the 2024 DST changes of both hemispheres.
+			for _, from := range []time.Time{
+				time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 10, 4, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC),
+			} {
+				for at := from; at.Before(from.Add(4 * 24 * time.Hour)); at = at.Add(293 * time.Minute) {
+					local := at.In(location)
+					got, gotOK := spec.next(local)
+					want, wantOK := scanNext(spec, local, 8*24*time.Hour)
+					if !wantOK && got.Sub(local) >= 8*24*time.Hour {
+						// Further away than the scan looks.
+						want, wantOK = got, gotOK
+					}
+					if gotOK != wantOK || !got.Equal(want) {
+						t.Fatalf("%s in %s: next(%s) = %s %t, want %s %t", expr, zone, local, got, gotOK, want, wantOK)
+					}
+					got, gotOK = spec.last(local, 3*24*time.Hour)
+					want, wantOK = scanLast(spec, local, 3*24*time.Hour)
+					if gotOK != wantOK || !got.Equal(want) {
+						t.Fatalf("%s in %s: last(%s) = %s %t, want %s %t", expr, zone, local, got, gotOK, want, wantOK)
+					}
+				}
+			}
+		}
+	}
+}
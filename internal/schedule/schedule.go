@@ -0,0 +1,283 @@
+package schedule
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxWindowDuration bounds a single window so the containing occurrence can
+// be found by scanning back from a point in time.
+const maxWindowDuration = 7 * 24 * time.Hour
+
+// maxTransitionSteps bounds the search for the next change of state.
+const maxTransitionSteps = 1000
+
+// File is the JSON schedule file.
+//
+//	{
+//	  "timezone": "Asia/Tokyo",
+//	  "outside": {"mine": false},
+//	  "windows": [
+//	    {"name": "night", "weekly": "mon-fri 22:00-06:00", "args": ["--threads=16"]},
+//	    {"name": "weekend", "cron": "0 0 * * sat", "duration": "48h"}
+//	  ]
+//	}
+//
+// The first window containing a point in time decides whether xmrig mines
+// and which extra args it runs with; outside every window Outside applies.
+type File struct {
+	Timezone string       `json:"timezone"`
+	Outside  *FileProfile `json:"outside"`
+	Windows  []FileWindow `json:"windows"`
+}
+
+type FileProfile struct {
+	Mine *bool    `json:"mine"`
+	Args []string `json:"args"`
+}
+
+type FileWindow struct {
+	Name     string   `json:"name"`
+	Weekly   string   `json:"weekly"`
+	Cron     string   `json:"cron"`
+	Duration string   `json:"duration"`
+	Mine     *bool    `json:"mine"`
+	Args     []string `json:"args"`
+}
+
+type Schedule struct {
+	location *time.Location
+	outside  Profile
+	windows  []Window
+}
+
+// Profile is what xmrig should do while a window, or no window, is active.
+type Profile struct {
+	Mine bool
+	Args []string
+}
+
+type Window struct {
+	Name     string
+	Spec     string
+	Duration time.Duration
+	Profile
+	cron cronSpec
+}
+
+// State is the schedule's verdict at a point in time. Window is nil outside
+// every window.
+type State struct {
+	Window *Window
+	Since  time.Time
+	Until  time.Time
+	Profile
+}
+
+func (s State) same(other State) bool {
+	return s.Window == other.Window
+}
+
+// Load reads and validates a schedule file.
+func Load(path string) (*Schedule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file File
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	schedule, err := New(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return schedule, nil
+}
+
+func New(file File) (*Schedule, error) {
+	location := time.UTC
+	if file.Timezone != "" {
+		loaded, err := time.LoadLocation(file.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("timezone: %w", err)
+		}
+		location = loaded
+	}
+	if len(file.Windows) == 0 {
+		return nil, errors.New("windows: at least one window is required")
+	}
+	schedule := &Schedule{location: location}
+	if file.Outside != nil {
+		schedule.outside = Profile{Mine: boolValue(file.Outside.Mine, false), Args: file.Outside.Args}
+	}
+	for i, raw := range file.Windows {
+		window, err := newWindow(raw)
+		if err != nil {
+			return nil, fmt.Errorf("windows[%d]: %w", i, err)
+		}
+		if window.Name == "" {
+			window.Name = "window-" + strconv.Itoa(i)
+		}
+		schedule.windows = append(schedule.windows, window)
+	}
+	return schedule, nil
+}
+
+func newWindow(raw FileWindow) (Window, error) {
+	window := Window{
+		Name:    raw.Name,
+		Profile: Profile{Mine: boolValue(raw.Mine, true), Args: raw.Args},
+	}
+	switch {
+	case raw.Weekly != "" && raw.Cron != "":
+		return Window{}, errors.New("set either weekly or cron, not both")
+	case raw.Weekly != "":
+		expr, duration, err := parseWeekly(raw.Weekly)
+		if err != nil {
+			return Window{}, fmt.Errorf("weekly: %w", err)
+		}
+		window.Spec = raw.Weekly
+		window.Duration = duration
+		if window.cron, err = parseCron(expr); err != nil {
+			return Window{}, fmt.Errorf("weekly: %w", err)
+		}
+	case raw.Cron != "":
+		var err error
+		if window.cron, err = parseCron(raw.Cron); err != nil {
+			return Window{}, fmt.Errorf("cron: %w", err)
+		}
+		if window.Duration, err = time.ParseDuration(raw.Duration); err != nil {
+			return Window{}, fmt.Errorf("duration: %w", err)
+		}
+		window.Spec = raw.Cron + " for " + window.Duration.String()
+	default:
+		return Window{}, errors.New("one of weekly or cron is required")
+	}
+	if window.Duration < time.Minute || window.Duration > maxWindowDuration {
+		return Window{}, fmt.Errorf("duration: must be between 1m and %s", maxWindowDuration)
+	}
+	return window, nil
+}
+
+// parseWeekly turns "mon-fri 22:00-06:00" into a cron expression firing at
+// the window start and the window's duration. An end at or before the start
+// crosses midnight.
+func parseWeekly(spec string) (string, time.Duration, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 2 {
+		return "", 0, errors.New(`expected "<days> HH:MM-HH:MM"`)
+	}
+	days := strings.ToLower(fields[0])
+	if days == "daily" {
+		days = "*"
+	}
+	rawStart, rawEnd, ok := strings.Cut(fields[1], "-")
+	if !ok {
+		return "", 0, fmt.Errorf("invalid time range %q", fields[1])
+	}
+	start, err := parseClock(rawStart)
+	if err != nil {
+		return "", 0, err
+	}
+	end, err := parseClock(rawEnd)
+	if err != nil {
+		return "", 0, err
+	}
+	if start == 24*time.Hour {
+		return "", 0, fmt.Errorf("invalid start %q", rawStart)
+	}
+	duration := end - start
+	if duration <= 0 {
+		duration += 24 * time.Hour
+	}
+	minutes := int(start / time.Minute)
+	return fmt.Sprintf("%d %d * * %s", minutes%60, minutes/60, days), duration, nil
+}
+
+func parseClock(raw string) (time.Duration, error) {
+	rawHour, rawMinute, ok := strings.Cut(raw, ":")
+	hour, hourErr := strconv.Atoi(rawHour)
+	minute, minuteErr := strconv.Atoi(rawMinute)
+	if !ok || hourErr != nil || minuteErr != nil || hour < 0 || hour > 24 || minute < 0 || minute > 59 || (hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("invalid time %q", raw)
+	}
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute, nil
+}
+
+func boolValue(value *bool, fallback bool) bool {
+	if value == nil {
+		return fallback
+	}
+	return *value
+}
+
+func (s *Schedule) Location() *time.Location {
+	return s.location
+}
+
+func (s *Schedule) Windows() []Window {
+	return append([]Window(nil), s.windows...)
+}
+
+// At returns the state in effect at t.
+func (s *Schedule) At(t time.Time) State {
+	t = t.In(s.location)
+	for i := range s.windows {
+		window := &s.windows[i]
+		start, ok := window.cron.last(t, window.Duration)
+		if !ok {
+			continue
+		}
+		return State{
+			Window:  window,
+			Since:   start,
+			Until:   start.Add(window.Duration),
+			Profile: window.Profile,
+		}
+	}
+	return State{Profile: s.outside}
+}
+
+// Next returns the first time after t at which the state changes and the
+// state from then on. It reports false when the state never changes.
+func (s *Schedule) Next(t time.Time) (time.Time, State, bool) {
+	current := s.At(t)
+	at := t.In(s.location)
+	for step := 0; step < maxTransitionSteps; step++ {
+		candidate, ok := s.nextBoundary(at)
+		if !ok {
+			return time.Time{}, State{}, false
+		}
+		if state := s.At(candidate); !state.same(current) {
+			return candidate, state, true
+		}
+		at = candidate
+	}
+	return time.Time{}, State{}, false
+}
+
+// nextBoundary returns the earliest window start or end after t.
+func (s *Schedule) nextBoundary(t time.Time) (time.Time, bool) {
+	var best time.Time
+	consider := func(candidate time.Time) {
+		if candidate.After(t) && (best.IsZero() || candidate.Before(best)) {
+			best = candidate
+		}
+	}
+	for _, window := range s.windows {
+		if start, ok := window.cron.last(t, window.Duration); ok {
+			consider(start.Add(window.Duration))
+		}
+		if start, ok := window.cron.next(t); ok {
+			consider(start)
+			consider(start.Add(window.Duration))
+		}
+	}
+	return best, !best.IsZero()
+}
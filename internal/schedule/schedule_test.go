@@ -0,0 +1,102 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func utc(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func newTestSchedule(t *testing.T, timezone string, windows ...FileWindow) *Schedule {
+	t.Helper()
+	schedule, err := New(File{Timezone: timezone, Windows: windows})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schedule
+}
+
+func TestScheduleUsesItsTimezone(t *testing.T) {
+	schedule := newTestSchedule(t, "Asia/Tokyo", FileWindow{Name: "night", Weekly: "mon-fri 22:00-06:00"})
+	tests := []struct {
+		at     string
+		window bool
+		since  string
+	}{
+		// Monday 22:30 in Tokyo, still Monday afternoon in UTC.
+		{"2024-01-01T13:30:00Z", true, "2024-01-01T13:00:00Z"},
+		// Saturday 05:00 in Tokyo, the tail of Friday's window.
+		{"2024-01-05T20:00:00Z", true, "2024-01-05T13:00:00Z"},
+		// Saturday 22:30 in Tokyo.
+		{"2024-01-06T13:30:00Z", false, ""},
+		// Monday 12:00 in Tokyo, Monday 03:00 UTC.
+		{"2024-01-01T03:00:00Z", false, ""},
+	}
+	for _, tt := range tests {
+		state := schedule.At(utc(tt.at))
+		if (state.Window != nil) != tt.window {
+			t.Errorf("At(%s) window = %v, want %t", tt.at, state.Window, tt.window)
+			continue
+		}
+		if tt.window && !state.Since.Equal(utc(tt.since)) {
+			t.Errorf("At(%s) since = %s, want %s", tt.at, state.Since.UTC(), tt.since)
+		}
+	}
+	at, state, ok := schedule.Next(utc("2024-01-01T03:00:00Z"))
+	if !ok || !at.Equal(utc("2024-01-01T13:00:00Z")) || state.Window == nil {
+		t.Fatalf("Next() = %s %v %t, want the night window at 13:00 UTC", at.UTC(), state.Window, ok)
+	}
+}
+
+func TestScheduleAcrossDSTChanges(t *testing.T) {
+	// 2024-03-10 02:00 EST jumps to 03:00 EDT; 2024-11-03 02:00 EDT falls
+	// back to 01:00 EST.
+	schedule := newTestSchedule(t, "America/New_York",
+		FileWindow{Name: "early", Weekly: "daily 01:00-04:00"},
+		FileWindow{Name: "skipped", Cron: "30 2 10 3 *", Duration: "30m"},
+	)
+	tests := []struct {
+		name   string
+		at     string
+		window string
+		since  string
+		until  string
+	}{
+		{"before the gap", "2024-03-10T06:30:00Z", "early", "2024-03-10T06:00:00Z", "2024-03-10T09:00:00Z"},
+		{"after the gap", "2024-03-10T08:30:00Z", "early", "2024-03-10T06:00:00Z", "2024-03-10T09:00:00Z"},
+		{"day after the gap", "2024-03-11T05:30:00Z", "early", "2024-03-11T05:00:00Z", "2024-03-11T08:00:00Z"},
+		{"first 01:30", "2024-11-03T05:30:00Z", "early", "2024-11-03T05:00:00Z", "2024-11-03T08:00:00Z"},
+		// 01:00 comes round again in EST and reopens the window until
+		// 04:00 EST.
+		{"repeated 01:30", "2024-11-03T06:30:00Z", "early", "2024-11-03T06:00:00Z", "2024-11-03T09:00:00Z"},
+		{"day after the repeat", "2024-11-04T06:30:00Z", "early", "2024-11-04T06:00:00Z", "2024-11-04T09:00:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := schedule.At(utc(tt.at))
+			if state.Window == nil || state.Window.Name != tt.window {
+				t.Fatalf("At(%s) = %v, want %s", tt.at, state.Window, tt.window)
+			}
+			if !state.Since.Equal(utc(tt.since)) || !state.Until.Equal(utc(tt.until)) {
+				t.Fatalf("At(%s) = %s-%s, want %s-%s", tt.at, state.Since.UTC(), state.Until.UTC(), tt.since, tt.until)
+			}
+		})
+	}
+
+	// 02:30 does not exist on 2024-03-10, so the "skipped" window never
+	// opens that year and the next change is the end of "early".
+	at, state, ok := schedule.Next(utc("2024-03-10T07:15:00Z"))
+	if !ok || !at.Equal(utc("2024-03-10T09:00:00Z")) || state.Window != nil {
+		t.Fatalf("Next() = %s %v %t, want outside at 09:00 UTC", at.UTC(), state.Window, ok)
+	}
+	at, state, ok = schedule.Next(utc("2024-03-10T09:00:00Z"))
+	if !ok || !at.Equal(utc("2024-03-11T05:00:00Z")) || state.Window == nil || state.Window.Name != "early" {
+		t.Fatalf("Next() = %s %v %t, want early at 05:00 UTC the next day", at.UTC(), state.Window, ok)
+	}
+}
@@ -0,0 +1,151 @@
+package specs
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// defaultClockTicks is USER_HZ on every architecture Linux supports; it is
+// only used when the kernel's auxiliary vector cannot be read.
+const defaultClockTicks = 100
+
+// atClockTicks is AT_CLKTCK, the auxiliary vector entry sysconf(_SC_CLK_TCK)
+// reads.
+const atClockTicks = 17
+
+var clockTicks = sync.OnceValue(func() uint64 {
+	data, err := os.ReadFile("/proc/self/auxv")
+	if err != nil {
+		return defaultClockTicks
+	}
+	if ticks, ok := parseAuxv(data, atClockTicks); ok && ticks > 0 {
+		return ticks
+	}
+	return defaultClockTicks
+})
+
+// ClockTicks is the unit of the CPU times in /proc/<pid>/stat, the value
+// sysconf(_SC_CLK_TCK) returns.
+func ClockTicks() uint64 {
+	return clockTicks()
+}
+
+// parseAuxv looks key up in an auxiliary vector of native-endian word pairs.
+func parseAuxv(data []byte, key uint64) (uint64, bool) {
+	word := strconv.IntSize / 8
+	for i := 0; i+2*word <= len(data); i += 2 * word {
+		var k, v uint64
+		if word == 8 {
+			k = binary.NativeEndian.Uint64(data[i:])
+			v = binary.NativeEndian.Uint64(data[i+word:])
+		} else {
+			k = uint64(binary.NativeEndian.Uint32(data[i:]))
+			v = uint64(binary.NativeEndian.Uint32(data[i+word:]))
+		}
+		if k == 0 {
+			break
+		}
+		if k == key {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// CPUTimes are the aggregate jiffies from the "cpu" line of /proc/stat.
+type CPUTimes struct {
+	Total uint64
+	Idle  uint64
+}
+
+// Busy is the time spent neither idle nor waiting on I/O.
+func (t CPUTimes) Busy() uint64 {
+	return t.Total - t.Idle
+}
+
+func ReadCPUTimes() (CPUTimes, error) {
+	data, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return CPUTimes{}, fmt.Errorf("failed to read /proc/stat: %w", err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 5 || fields[0] != "cpu" {
+			continue
+		}
+		var times CPUTimes
+		// user nice system idle iowait irq softirq steal; guest time is
+		// already counted in user and nice.
+		for i, field := range fields[1:] {
+			if i >= 8 {
+				break
+			}
+			value, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				return CPUTimes{}, fmt.Errorf("invalid /proc/stat value %q", field)
+			}
+			times.Total += value
+			if i == 3 || i == 4 {
+				times.Idle += value
+			}
+		}
+		return times, nil
+	}
+	return CPUTimes{}, fmt.Errorf("cpu line not found in /proc/stat")
+}
+
+// ProcessStat is the part of /proc/<pid>/stat grid-node uses. Times are in
+// clock ticks and include all of the process's threads.
+type ProcessStat struct {
+	UserTicks   uint64
+	SystemTicks uint64
+	Nice        int
+	Threads     int
+}
+
+// ReadProcessCPUTime returns the user and system jiffies of a process,
+// including all of its threads.
+func ReadProcessCPUTime(pid int) (uint64, error) {
+	stat, err := ReadProcessStat(pid)
+	if err != nil {
+		return 0, err
+	}
+	return stat.UserTicks + stat.SystemTicks, nil
+}
+
+func ReadProcessStat(pid int) (ProcessStat, error) {
+	path := fmt.Sprintf("/proc/%d/stat", pid)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ProcessStat{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	// The command name may contain spaces and parentheses, so fields are
+	// counted after the last ")"; offsets are stat(5) field numbers minus
+	// three.
+	end := strings.LastIndexByte(string(data), ')')
+	if end < 0 {
+		return ProcessStat{}, fmt.Errorf("invalid %s", path)
+	}
+	fields := strings.Fields(string(data)[end+1:])
+	if len(fields) < 18 {
+		return ProcessStat{}, fmt.Errorf("invalid %s", path)
+	}
+	var stat ProcessStat
+	if stat.UserTicks, err = strconv.ParseUint(fields[11], 10, 64); err != nil {
+		return ProcessStat{}, fmt.Errorf("invalid utime in %s", path)
+	}
+	if stat.SystemTicks, err = strconv.ParseUint(fields[12], 10, 64); err != nil {
+		return ProcessStat{}, fmt.Errorf("invalid stime in %s", path)
+	}
+	if stat.Nice, err = strconv.Atoi(fields[16]); err != nil {
+		return ProcessStat{}, fmt.Errorf("invalid nice in %s", path)
+	}
+	if stat.Threads, err = strconv.Atoi(fields[17]); err != nil {
+		return ProcessStat{}, fmt.Errorf("invalid num_threads in %s", path)
+	}
+	return stat, nil
+}
@@ -0,0 +1,33 @@
+package specs
+
+import (
+	"encoding/binary"
+	"strconv"
+	"testing"
+)
+
+func auxv(pairs ...uint64) []byte {
+	word := strconv.IntSize / 8
+	data := make([]byte, len(pairs)*word)
+	for i, value := range pairs {
+		if word == 8 {
+			binary.NativeEndian.PutUint64(data[i*word:], value)
+		} else {
+			binary.NativeEndian.PutUint32(data[i*word:], uint32(value))
+		}
+	}
+	return data
+}
+
+func TestParseAuxv(t *testing.T) {
+	data := auxv(6, 4096, atClockTicks, 250, 0, 0, atClockTicks, 1)
+	if ticks, ok := parseAuxv(data, atClockTicks); !ok || ticks != 250 {
+		t.Fatalf("parseAuxv() = %d %t, want 250", ticks, ok)
+	}
+	if _, ok := parseAuxv(data[:len(data)-1], 99); ok {
+		t.Fatal("parseAuxv() found a missing key")
+	}
+	if ticks := ClockTicks(); ticks == 0 {
+		t.Fatal("ClockTicks() = 0")
+	}
+}
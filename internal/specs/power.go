@@ -11,26 +11,40 @@ import (
 )
 
 func readCPUWattage() string {
-	if _, err := exec.LookPath("turbostat"); err != nil {
+	value, ok := readCPUWattageValue()
+	if !ok {
 		return ""
 	}
+	return fmt.Sprintf("%.1f W", value)
+}
+
+func ReadCPUWattage() string {
+	return readCPUWattage()
+}
+
+// ReadCPUWattageValue returns the CPU package power in watts, averaged by
+// turbostat over its default interval.
+func ReadCPUWattageValue() (float64, bool) {
+	return readCPUWattageValue()
+}
+
+func readCPUWattageValue() (float64, bool) {
+	if _, err := exec.LookPath("turbostat"); err != nil {
+		return 0, false
+	}
 
 	baseArgs := []string{"--Summary", "--quiet", "--show", "PkgWatt", "-n", "1"}
 	sudoArgs := append([]string{"-n", "turbostat"}, baseArgs...)
 	out, err := exec.Command("sudo", sudoArgs...).Output()
 	if err != nil {
-		return ""
+		return 0, false
 	}
 
 	value := parseTurbostatPkgWatt(out)
 	if value <= 0 {
-		return ""
+		return 0, false
 	}
-	return fmt.Sprintf("%.1f W", value)
-}
-
-func ReadCPUWattage() string {
-	return readCPUWattage()
+	return value, true
 }
 
 func parseTurbostatPkgWatt(out []byte) float64 {
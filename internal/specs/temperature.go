@@ -23,10 +23,26 @@ func ReadCPUTemp() string {
 	return readCPUTemp()
 }
 
+// ReadCPUTempCelsius returns the hottest CPU temperature in degrees Celsius.
+func ReadCPUTempCelsius() (float64, bool) {
+	if temp, ok := readSensorsTempValue(); ok {
+		return temp, true
+	}
+	return readSysfsTempValue()
+}
+
 func readSysfsTemp() string {
+	temp, ok := readSysfsTempValue()
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%.1f C", temp)
+}
+
+func readSysfsTempValue() (float64, bool) {
 	zones, _ := filepath.Glob("/sys/class/thermal/thermal_zone*")
 	if len(zones) == 0 {
-		return ""
+		return 0, false
 	}
 
 	var maxTemp float64
@@ -48,26 +64,26 @@ func readSysfsTemp() string {
 		}
 	}
 
-	if !found {
+	return maxTemp, found
+}
+
+func readSensorsTemp() string {
+	temp, ok := readSensorsTempValue()
+	if !ok {
 		return ""
 	}
-	return fmt.Sprintf("%.1f C", maxTemp)
+	return fmt.Sprintf("%.1f C", temp)
 }
 
-func readSensorsTemp() string {
+func readSensorsTempValue() (float64, bool) {
 	if _, err := exec.LookPath("sensors"); err != nil {
-		return ""
+		return 0, false
 	}
 	out, err := exec.Command("sensors").Output()
 	if err != nil {
-		return ""
+		return 0, false
 	}
-
-	temp, ok := parseSensorsOutput(out)
-	if !ok {
-		return ""
-	}
-	return fmt.Sprintf("%.1f C", temp)
+	return parseSensorsOutput(out)
 }
 
 func parseSensorsOutput(out []byte) (float64, bool) {
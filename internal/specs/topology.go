@@ -0,0 +1,104 @@
+package specs
+
+import (
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CPU is one logical CPU and the physical core and package it belongs to.
+type CPU struct {
+	ID      int
+	Core    int
+	Package int
+}
+
+// Topology lists the online logical CPUs, sorted by ID, and the total L3
+// cache size.
+type Topology struct {
+	CPUs    []CPU
+	L3Bytes int64
+}
+
+// ReadTopology reads the CPU layout from sysfs. Without sysfs every logical
+// CPU is reported as its own core.
+func ReadTopology() Topology {
+	var topology Topology
+	dirs, _ := filepath.Glob("/sys/devices/system/cpu/cpu[0-9]*")
+	l3 := make(map[string]int64)
+	for _, dir := range dirs {
+		id, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(dir), "cpu"))
+		if err != nil {
+			continue
+		}
+		// Offline CPUs have no topology directory.
+		core, err := strconv.Atoi(readTopologyFile(filepath.Join(dir, "topology", "core_id")))
+		if err != nil {
+			continue
+		}
+		pkg, _ := strconv.Atoi(readTopologyFile(filepath.Join(dir, "topology", "physical_package_id")))
+		topology.CPUs = append(topology.CPUs, CPU{ID: id, Core: core, Package: pkg})
+		caches, _ := filepath.Glob(filepath.Join(dir, "cache", "index[0-9]*"))
+		for _, cache := range caches {
+			if readTopologyFile(filepath.Join(cache, "level")) != "3" {
+				continue
+			}
+			shared := readTopologyFile(filepath.Join(cache, "shared_cpu_list"))
+			if _, seen := l3[shared]; !seen {
+				l3[shared] = parseCacheSize(readTopologyFile(filepath.Join(cache, "size")))
+			}
+		}
+	}
+	if len(topology.CPUs) == 0 {
+		for id := 0; id < runtime.NumCPU(); id++ {
+			topology.CPUs = append(topology.CPUs, CPU{ID: id, Core: id})
+		}
+	}
+	sort.Slice(topology.CPUs, func(i, j int) bool {
+		return topology.CPUs[i].ID < topology.CPUs[j].ID
+	})
+	for _, size := range l3 {
+		topology.L3Bytes += size
+	}
+	return topology
+}
+
+// Cores is the number of physical cores.
+func (t Topology) Cores() int {
+	cores := make(map[[2]int]struct{})
+	for _, cpu := range t.CPUs {
+		cores[[2]int{cpu.Package, cpu.Core}] = struct{}{}
+	}
+	return len(cores)
+}
+
+// Packages is the number of CPU sockets.
+func (t Topology) Packages() int {
+	packages := make(map[int]struct{})
+	for _, cpu := range t.CPUs {
+		packages[cpu.Package] = struct{}{}
+	}
+	return len(packages)
+}
+
+// parseCacheSize parses sysfs cache sizes such as "32768K".
+func parseCacheSize(value string) int64 {
+	multiplier := int64(1)
+	switch {
+	case strings.HasSuffix(value, "K"):
+		multiplier = 1 << 10
+	case strings.HasSuffix(value, "M"):
+		multiplier = 1 << 20
+	}
+	size, err := strconv.ParseInt(strings.TrimRight(value, "KM"), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return size * multiplier
+}
+
+func readTopologyFile(path string) string {
+	return strings.TrimSpace(readSysfsFile(path))
+}
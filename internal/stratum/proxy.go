@@ -0,0 +1,263 @@
+package stratum
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+const defaultListen = "127.0.0.1:0"
+
+const defaultMaxOutage = 2 * time.Minute
+
+const defaultReconnectDelay = 2 * time.Second
+
+const defaultDialTimeout = 10 * time.Second
+
+var errNoUpstream = errors.New("no upstream pool configured")
+
+// Config configures the proxy. Dial is mainly a seam for running the proxy
+// against an in-process pool; by default it dials TCP, or TLS when the
+// upstream asks for it.
+type Config struct {
+	// Listen is the address miners connect to.
+	Listen string
+	// MaxOutage is how long a miner is kept connected while the upstream is
+	// unreachable. Past it the miner is disconnected so it notices.
+	MaxOutage      time.Duration
+	ReconnectDelay time.Duration
+	DialTimeout    time.Duration
+	Dial           func(ctx context.Context, upstream Upstream) (net.Conn, error)
+}
+
+// Upstream is the pool the proxy forwards to.
+type Upstream struct {
+	Address string
+	TLS     bool
+}
+
+// Stats are counted from the stratum traffic itself.
+type Stats struct {
+	Address           string
+	Upstream          string
+	UpstreamConnected bool
+	Miners            int
+	Reconnects        uint64
+	Jobs              uint64
+	Submitted         uint64
+	Accepted          uint64
+	Rejected          uint64
+	LastJob           time.Time
+	LastError         string
+	LastErrorAt       time.Time
+}
+
+// Proxy relays Monero-style stratum (line-delimited JSON-RPC over TCP)
+// between local miners and one upstream pool. Each miner gets its own
+// upstream connection, which is re-established behind the miner's back when
+// it drops.
+type Proxy struct {
+	config   Config
+	listener net.Listener
+
+	mu        sync.Mutex
+	upstream  Upstream
+	onError   func(address string, err error)
+	sessions  map[*session]struct{}
+	stats     Stats
+	connected int
+}
+
+// Listen binds the proxy's listener; Serve accepts miners on it.
+func Listen(cfg Config) (*Proxy, error) {
+	if cfg.Listen == "" {
+		cfg.Listen = defaultListen
+	}
+	if cfg.MaxOutage <= 0 {
+		cfg.MaxOutage = defaultMaxOutage
+	}
+	if cfg.ReconnectDelay <= 0 {
+		cfg.ReconnectDelay = defaultReconnectDelay
+	}
+	if cfg.DialTimeout <= 0 {
+		cfg.DialTimeout = defaultDialTimeout
+	}
+	listener, err := net.Listen("tcp", cfg.Listen)
+	if err != nil {
+		return nil, err
+	}
+	p := &Proxy{
+		config:   cfg,
+		listener: listener,
+		sessions: make(map[*session]struct{}),
+	}
+	if p.config.Dial == nil {
+		p.config.Dial = p.dial
+	}
+	p.stats.Address = listener.Addr().String()
+	return p, nil
+}
+
+// Addr is the address miners should connect to.
+func (p *Proxy) Addr() string {
+	return p.listener.Addr().String()
+}
+
+// SetUpstream changes the pool for miners connecting from now on; existing
+// sessions keep their pool until they reconnect.
+func (p *Proxy) SetUpstream(upstream Upstream) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.upstream = upstream
+	p.stats.Upstream = upstream.Address
+}
+
+// OnUpstreamError registers fn to be called when the proxy gives up on the
+// upstream: the pool rejected the login, or it stayed unreachable for longer
+// than MaxOutage. Drops the proxy recovers from within that budget only show
+// in Stats.
+func (p *Proxy) OnUpstreamError(fn func(address string, err error)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.onError = fn
+}
+
+func (p *Proxy) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := p.stats
+	stats.Miners = len(p.sessions)
+	stats.UpstreamConnected = p.connected > 0
+	return stats
+}
+
+// Serve accepts miners until ctx is done, then closes every session.
+func (p *Proxy) Serve(ctx context.Context) error {
+	go func() {
+		<-ctx.Done()
+		p.listener.Close()
+	}()
+	var sessions sync.WaitGroup
+	defer sessions.Wait()
+	for {
+		conn, err := p.listener.Accept()
+		if err != nil {
+			p.closeSessions()
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		s := newSession(ctx, p, conn)
+		p.addSession(s)
+		sessions.Add(1)
+		go func() {
+			defer sessions.Done()
+			defer p.removeSession(s)
+			s.run()
+		}()
+	}
+}
+
+func (p *Proxy) dial(ctx context.Context, upstream Upstream) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: p.config.DialTimeout, KeepAlive: 30 * time.Second}
+	if !upstream.TLS {
+		return dialer.DialContext(ctx, "tcp", upstream.Address)
+	}
+	host, _, err := net.SplitHostPort(upstream.Address)
+	if err != nil {
+		return nil, err
+	}
+	tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: host}}
+	return tlsDialer.DialContext(ctx, "tcp", upstream.Address)
+}
+
+func (p *Proxy) currentUpstream() (Upstream, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.upstream.Address == "" {
+		return Upstream{}, errNoUpstream
+	}
+	return p.upstream, nil
+}
+
+func (p *Proxy) addSession(s *session) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sessions[s] = struct{}{}
+}
+
+func (p *Proxy) removeSession(s *session) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.sessions, s)
+}
+
+func (p *Proxy) closeSessions() {
+	p.mu.Lock()
+	sessions := make([]*session, 0, len(p.sessions))
+	for s := range p.sessions {
+		sessions = append(sessions, s)
+	}
+	p.mu.Unlock()
+	for _, s := range sessions {
+		s.close()
+	}
+}
+
+func (p *Proxy) recordError(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stats.LastError = err.Error()
+	p.stats.LastErrorAt = time.Now().UTC()
+}
+
+// reportFailure records err and passes it on to the OnUpstreamError callback.
+func (p *Proxy) reportFailure(address string, err error) {
+	p.recordError(err)
+	p.mu.Lock()
+	onError := p.onError
+	p.mu.Unlock()
+	if onError != nil {
+		onError(address, err)
+	}
+}
+
+func (p *Proxy) recordConnected(connected bool, reconnect bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if connected {
+		p.connected++
+	} else {
+		p.connected--
+	}
+	if reconnect {
+		p.stats.Reconnects++
+	}
+}
+
+func (p *Proxy) recordJob() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stats.Jobs++
+	p.stats.LastJob = time.Now().UTC()
+}
+
+func (p *Proxy) recordSubmit() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stats.Submitted++
+}
+
+func (p *Proxy) recordResult(accepted bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if accepted {
+		p.stats.Accepted++
+	} else {
+		p.stats.Rejected++
+	}
+}
@@ -0,0 +1,276 @@
+package stratum
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakePool answers logins with a fresh session id and job, accepts submits
+// unless their nonce is "bad" and records the session id each submit
+// carried.
+type fakePool struct {
+	listener net.Listener
+
+	mu        sync.Mutex
+	conns     []net.Conn
+	logins    int
+	submitIDs []string
+}
+
+type submitParams struct {
+	ID    string `json:"id"`
+	JobID string `json:"job_id"`
+	Nonce string `json:"nonce"`
+}
+
+func startFakePool(t *testing.T) *fakePool {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := &fakePool{listener: listener}
+	t.Cleanup(func() {
+		listener.Close()
+		pool.drop()
+	})
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			pool.mu.Lock()
+			pool.conns = append(pool.conns, conn)
+			pool.mu.Unlock()
+			go pool.handle(conn)
+		}
+	}()
+	return pool
+}
+
+func (p *fakePool) handle(conn net.Conn) {
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		var msg message
+		if json.Unmarshal(scanner.Bytes(), &msg) != nil {
+			continue
+		}
+		reply := message{ID: msg.ID, JSONRPC: "2.0"}
+		switch msg.Method {
+		case "login":
+			p.mu.Lock()
+			p.logins++
+			id := fmt.Sprintf("sess-%d", p.logins)
+			p.mu.Unlock()
+			reply.Result, _ = json.Marshal(map[string]interface{}{
+				"id":     id,
+				"job":    map[string]string{"job_id": "login-" + id, "blob": "00", "target": "ffff"},
+				"status": "OK",
+			})
+		case "submit":
+			var params submitParams
+			_ = json.Unmarshal(msg.Params, &params)
+			p.mu.Lock()
+			p.submitIDs = append(p.submitIDs, params.ID)
+			p.mu.Unlock()
+			if params.Nonce == "bad" {
+				reply.Error = errorObject("Low difficulty share")
+			} else {
+				reply.Result = json.RawMessage(`{"status":"OK"}`)
+			}
+		default:
+			continue
+		}
+		if writeLine(conn, reply) != nil {
+			return
+		}
+	}
+}
+
+// push sends a job notification on the newest connection.
+func (p *fakePool) push(t *testing.T, jobID string) {
+	t.Helper()
+	p.mu.Lock()
+	conn := p.conns[len(p.conns)-1]
+	p.mu.Unlock()
+	params, _ := json.Marshal(map[string]string{"job_id": jobID, "blob": "00", "target": "ffff"})
+	if err := writeLine(conn, message{JSONRPC: "2.0", Method: "job", Params: params}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// drop closes every pool connection as if the pool went away briefly.
+func (p *fakePool) drop() {
+	p.mu.Lock()
+	conns := p.conns
+	p.conns = nil
+	p.mu.Unlock()
+	for _, conn := range conns {
+		conn.Close()
+	}
+}
+
+func (p *fakePool) lastSubmitID() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.submitIDs) == 0 {
+		return ""
+	}
+	return p.submitIDs[len(p.submitIDs)-1]
+}
+
+// testMiner speaks stratum to the proxy like xmrig does.
+type testMiner struct {
+	t       *testing.T
+	conn    net.Conn
+	scanner *bufio.Scanner
+	nextID  int
+}
+
+func (m *testMiner) send(method string, params interface{}) json.RawMessage {
+	m.t.Helper()
+	m.nextID++
+	id := json.RawMessage(fmt.Sprint(m.nextID))
+	raw, _ := json.Marshal(params)
+	if err := writeLine(m.conn, message{ID: id, JSONRPC: "2.0", Method: method, Params: raw}); err != nil {
+		m.t.Fatal(err)
+	}
+	return id
+}
+
+func (m *testMiner) read() message {
+	m.t.Helper()
+	_ = m.conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if !m.scanner.Scan() {
+		m.t.Fatalf("miner connection closed: %v", m.scanner.Err())
+	}
+	var msg message
+	if err := json.Unmarshal(m.scanner.Bytes(), &msg); err != nil {
+		m.t.Fatal(err)
+	}
+	return msg
+}
+
+func (m *testMiner) submit(sessionID, nonce string) message {
+	m.t.Helper()
+	id := m.send("submit", submitParams{ID: sessionID, JobID: "job", Nonce: nonce})
+	reply := m.read()
+	if string(reply.ID) != string(id) {
+		m.t.Fatalf("reply id = %s, want %s", reply.ID, id)
+	}
+	return reply
+}
+
+func jobID(t *testing.T, job json.RawMessage) string {
+	t.Helper()
+	var fields struct {
+		JobID string `json:"job_id"`
+	}
+	if err := json.Unmarshal(job, &fields); err != nil {
+		t.Fatal(err)
+	}
+	return fields.JobID
+}
+
+func TestProxyRelaysAndReconnects(t *testing.T) {
+	pool := startFakePool(t)
+	var dials int
+	var dialsMu sync.Mutex
+	proxy, err := Listen(Config{
+		ReconnectDelay: 10 * time.Millisecond,
+		Dial: func(ctx context.Context, upstream Upstream) (net.Conn, error) {
+			dialsMu.Lock()
+			dials++
+			dialsMu.Unlock()
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "tcp", upstream.Address)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy.SetUpstream(Upstream{Address: pool.listener.Addr().String()})
+	var failures int
+	proxy.OnUpstreamError(func(string, error) {
+		dialsMu.Lock()
+		failures++
+		dialsMu.Unlock()
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- proxy.Serve(ctx) }()
+	defer func() {
+		cancel()
+		if err := <-served; err != nil {
+			t.Errorf("serve: %v", err)
+		}
+	}()
+
+	conn, err := net.Dial("tcp", proxy.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	miner := &testMiner{t: t, conn: conn, scanner: newScanner(conn)}
+
+	miner.send("login", map[string]string{"login": "wallet", "pass": "x", "agent": "test"})
+	var login loginResult
+	if err := json.Unmarshal(miner.read().Result, &login); err != nil {
+		t.Fatal(err)
+	}
+	if login.ID != "sess-1" || jobID(t, login.Job) != "login-sess-1" {
+		t.Fatalf("login = %s %s, want sess-1 with job login-sess-1", login.ID, login.Job)
+	}
+
+	pool.push(t, "job-2")
+	if job := miner.read(); job.Method != "job" || jobID(t, job.Params) != "job-2" {
+		t.Fatalf("notification = %s %s, want job job-2", job.Method, job.Params)
+	}
+
+	if reply := miner.submit(login.ID, "good"); reply.failed() {
+		t.Fatalf("good share rejected: %s", reply.Error)
+	}
+	if reply := miner.submit(login.ID, "bad"); !reply.failed() {
+		t.Fatal("bad share accepted")
+	}
+	stats := proxy.Stats()
+	if stats.Submitted != 2 || stats.Accepted != 1 || stats.Rejected != 1 {
+		t.Fatalf("shares submitted/accepted/rejected = %d/%d/%d, want 2/1/1", stats.Submitted, stats.Accepted, stats.Rejected)
+	}
+	if stats.Jobs != 2 || stats.Miners != 1 || !stats.UpstreamConnected {
+		t.Fatalf("stats = %+v, want 2 jobs and one connected miner", stats)
+	}
+
+	pool.drop()
+	job := miner.read()
+	if job.Method != "job" || jobID(t, job.Params) != "login-sess-2" {
+		t.Fatalf("after reconnect got %s %s, want job login-sess-2", job.Method, job.Params)
+	}
+	// The miner still submits with its first session id; the proxy rewrites
+	// it to the new upstream session.
+	if reply := miner.submit(login.ID, "good"); reply.failed() {
+		t.Fatalf("share after reconnect rejected: %s", reply.Error)
+	}
+	if id := pool.lastSubmitID(); id != "sess-2" {
+		t.Fatalf("pool saw session id %q, want sess-2", id)
+	}
+	stats = proxy.Stats()
+	if stats.Reconnects != 1 || stats.Accepted != 2 || stats.Miners != 1 {
+		t.Fatalf("stats = %+v, want one reconnect, 2 accepted and the miner still connected", stats)
+	}
+	dialsMu.Lock()
+	defer dialsMu.Unlock()
+	if dials != 2 {
+		t.Fatalf("dials = %d, want 2", dials)
+	}
+	if failures != 0 {
+		t.Fatalf("upstream failures reported = %d, want 0 for a drop within MaxOutage", failures)
+	}
+}
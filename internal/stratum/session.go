@@ -0,0 +1,419 @@
+package stratum
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// maxLineSize bounds a single JSON-RPC message.
+const maxLineSize = 1 << 20
+
+var errUpstreamOutage = errors.New("upstream unreachable for too long")
+
+// message is any stratum request, response or notification. Lines are
+// forwarded as received unless a field has to be rewritten.
+type message struct {
+	ID      json.RawMessage `json:"id,omitempty"`
+	JSONRPC string          `json:"jsonrpc,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   json.RawMessage `json:"error,omitempty"`
+}
+
+func (m message) failed() bool {
+	return len(m.Error) > 0 && !bytes.Equal(m.Error, []byte("null"))
+}
+
+// loginResult is the part of the pool's login reply the proxy needs.
+type loginResult struct {
+	ID  string          `json:"id"`
+	Job json.RawMessage `json:"job"`
+}
+
+// loginRejectedError is a login the pool answered with an error; retrying
+// with the same credentials will not help.
+type loginRejectedError struct {
+	reply message
+}
+
+func (e *loginRejectedError) Error() string {
+	return fmt.Sprintf("login rejected: %s", e.reply.Error)
+}
+
+type upstreamConn struct {
+	conn    net.Conn
+	scanner *bufio.Scanner
+	address string
+	// id is the session id the pool assigned at login.
+	id string
+}
+
+// session is one miner connection and the upstream connection behind it.
+type session struct {
+	proxy  *Proxy
+	miner  net.Conn
+	ctx    context.Context
+	cancel context.CancelFunc
+	once   sync.Once
+
+	writeMu sync.Mutex
+
+	mu       sync.Mutex
+	upstream *upstreamConn
+	pending  map[string]json.RawMessage
+
+	login message
+	// minerID is the session id the miner received at its login; submits
+	// carry it and are rewritten after the upstream session changed.
+	minerID string
+}
+
+func newSession(ctx context.Context, proxy *Proxy, miner net.Conn) *session {
+	s := &session{
+		proxy:   proxy,
+		miner:   miner,
+		pending: make(map[string]json.RawMessage),
+	}
+	s.ctx, s.cancel = context.WithCancel(ctx)
+	return s
+}
+
+func (s *session) run() {
+	defer s.close()
+
+	scanner := newScanner(s.miner)
+	if !scanner.Scan() {
+		return
+	}
+	if err := json.Unmarshal(scanner.Bytes(), &s.login); err != nil || s.login.Method != "login" {
+		return
+	}
+	up, reply, err := s.connect()
+	if err != nil {
+		var rejected *loginRejectedError
+		if errors.As(err, &rejected) {
+			_ = s.writeMiner(rejected.reply)
+		}
+		return
+	}
+	var result loginResult
+	_ = json.Unmarshal(reply.Result, &result)
+	s.minerID = result.ID
+	if err := s.writeMiner(reply); err != nil {
+		up.conn.Close()
+		return
+	}
+	if len(result.Job) > 0 {
+		s.proxy.recordJob()
+	}
+	s.setUpstream(up, false)
+	go s.relayUpstream(up)
+
+	for scanner.Scan() {
+		s.handleMiner(scanner.Bytes())
+	}
+}
+
+// connect dials the upstream and logs in with the miner's credentials,
+// retrying until MaxOutage has passed.
+func (s *session) connect() (*upstreamConn, message, error) {
+	deadline := time.Now().Add(s.proxy.config.MaxOutage)
+	for {
+		upstream, err := s.proxy.currentUpstream()
+		if err == nil {
+			up, reply, loginErr := s.dialAndLogin(upstream)
+			if loginErr == nil {
+				return up, reply, nil
+			}
+			err = loginErr
+		}
+		s.proxy.recordError(err)
+		var rejected *loginRejectedError
+		if errors.As(err, &rejected) {
+			s.proxy.reportFailure(upstream.Address, err)
+			return nil, message{}, err
+		}
+		if time.Now().After(deadline) {
+			s.proxy.reportFailure(upstream.Address, fmt.Errorf("%w: %v", errUpstreamOutage, err))
+			return nil, message{}, errUpstreamOutage
+		}
+		timer := time.NewTimer(s.proxy.config.ReconnectDelay)
+		select {
+		case <-s.ctx.Done():
+			timer.Stop()
+			return nil, message{}, s.ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (s *session) dialAndLogin(upstream Upstream) (*upstreamConn, message, error) {
+	dialCtx, cancel := context.WithTimeout(s.ctx, s.proxy.config.DialTimeout)
+	defer cancel()
+	conn, err := s.proxy.config.Dial(dialCtx, upstream)
+	if err != nil {
+		return nil, message{}, err
+	}
+	up := &upstreamConn{conn: conn, scanner: newScanner(conn), address: upstream.Address}
+	fail := func(err error) (*upstreamConn, message, error) {
+		conn.Close()
+		return nil, message{}, err
+	}
+
+	_ = conn.SetDeadline(time.Now().Add(s.proxy.config.DialTimeout))
+	if err := writeLine(conn, s.login); err != nil {
+		return fail(err)
+	}
+	for up.scanner.Scan() {
+		var reply message
+		if err := json.Unmarshal(up.scanner.Bytes(), &reply); err != nil {
+			return fail(fmt.Errorf("invalid login reply: %w", err))
+		}
+		if reply.Method != "" || !bytes.Equal(reply.ID, s.login.ID) {
+			continue
+		}
+		if reply.failed() {
+			conn.Close()
+			return nil, message{}, &loginRejectedError{reply: reply}
+		}
+		var result loginResult
+		if err := json.Unmarshal(reply.Result, &result); err != nil {
+			return fail(fmt.Errorf("invalid login reply: %w", err))
+		}
+		up.id = result.ID
+		_ = conn.SetDeadline(time.Time{})
+		return up, reply, nil
+	}
+	if err := up.scanner.Err(); err != nil {
+		return fail(err)
+	}
+	return fail(errors.New("connection closed during login"))
+}
+
+// relayUpstream forwards pool traffic to the miner and reconnects whenever
+// the pool connection drops. The fresh login's job is handed to the miner
+// as a job notification, so it keeps mining on its existing connection.
+func (s *session) relayUpstream(up *upstreamConn) {
+	for {
+		err := s.pump(up)
+		if s.ctx.Err() != nil {
+			return
+		}
+		s.dropUpstream(up)
+		if err == nil {
+			err = errors.New("connection closed by pool")
+		}
+		s.proxy.recordError(err)
+
+		next, reply, err := s.connect()
+		if err != nil {
+			s.close()
+			return
+		}
+		s.setUpstream(next, true)
+		var result loginResult
+		_ = json.Unmarshal(reply.Result, &result)
+		if len(result.Job) > 0 {
+			s.proxy.recordJob()
+			if err := s.writeMiner(message{JSONRPC: "2.0", Method: "job", Params: result.Job}); err != nil {
+				s.close()
+				return
+			}
+		}
+		up = next
+	}
+}
+
+// pump forwards pool lines until the connection fails.
+func (s *session) pump(up *upstreamConn) error {
+	for up.scanner.Scan() {
+		line := up.scanner.Bytes()
+		var msg message
+		if err := json.Unmarshal(line, &msg); err == nil {
+			switch {
+			case msg.Method == "job":
+				s.proxy.recordJob()
+			case msg.Method == "" && s.takePending(msg.ID):
+				s.proxy.recordResult(!msg.failed())
+			}
+		}
+		if err := s.writeMinerRaw(line); err != nil {
+			s.close()
+			return err
+		}
+	}
+	return up.scanner.Err()
+}
+
+func (s *session) handleMiner(line []byte) {
+	var msg message
+	if err := json.Unmarshal(line, &msg); err != nil {
+		return
+	}
+	up := s.currentUpstream()
+	if msg.Method == "submit" {
+		s.proxy.recordSubmit()
+	}
+	if up == nil {
+		s.answerLocally(msg)
+		return
+	}
+	if s.minerID != up.id {
+		msg.Params = rewriteSessionID(msg.Params, up.id)
+		line, _ = json.Marshal(msg)
+	}
+	if msg.Method == "submit" {
+		s.addPending(msg.ID)
+	}
+	if _, err := up.conn.Write(append(append([]byte(nil), line...), '\n')); err != nil {
+		// The relay notices the broken connection and rejects the
+		// pending submit.
+		up.conn.Close()
+	}
+}
+
+// answerLocally keeps the miner going while the upstream is reconnecting.
+func (s *session) answerLocally(msg message) {
+	if len(msg.ID) == 0 {
+		return
+	}
+	reply := message{ID: msg.ID, JSONRPC: "2.0"}
+	switch msg.Method {
+	case "keepalived":
+		reply.Result = json.RawMessage(`{"status":"KEEPALIVED"}`)
+	case "submit":
+		s.proxy.recordResult(false)
+		reply.Error = errorObject("upstream pool reconnecting")
+	default:
+		reply.Error = errorObject("upstream pool reconnecting")
+	}
+	_ = s.writeMiner(reply)
+}
+
+func (s *session) currentUpstream() *upstreamConn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.upstream
+}
+
+func (s *session) setUpstream(up *upstreamConn, reconnect bool) {
+	s.mu.Lock()
+	s.upstream = up
+	s.mu.Unlock()
+	s.proxy.recordConnected(true, reconnect)
+}
+
+// dropUpstream forgets a broken upstream and rejects the submits that were
+// waiting on it.
+func (s *session) dropUpstream(up *upstreamConn) {
+	s.mu.Lock()
+	if s.upstream != up {
+		s.mu.Unlock()
+		return
+	}
+	s.upstream = nil
+	pending := s.pending
+	s.pending = make(map[string]json.RawMessage)
+	s.mu.Unlock()
+	up.conn.Close()
+	s.proxy.recordConnected(false, false)
+	for _, id := range pending {
+		s.proxy.recordResult(false)
+		_ = s.writeMiner(message{ID: id, JSONRPC: "2.0", Error: errorObject("upstream pool connection lost")})
+	}
+}
+
+func (s *session) addPending(id json.RawMessage) {
+	if len(id) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending[string(id)] = id
+}
+
+func (s *session) takePending(id json.RawMessage) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.pending[string(id)]; !ok {
+		return false
+	}
+	delete(s.pending, string(id))
+	return true
+}
+
+func (s *session) close() {
+	s.once.Do(func() {
+		s.cancel()
+		s.miner.Close()
+		s.mu.Lock()
+		up := s.upstream
+		s.upstream = nil
+		s.mu.Unlock()
+		if up != nil {
+			up.conn.Close()
+			s.proxy.recordConnected(false, false)
+		}
+	})
+}
+
+func (s *session) writeMiner(msg message) error {
+	line, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return s.writeMinerRaw(line)
+}
+
+func (s *session) writeMinerRaw(line []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	_, err := s.miner.Write(append(append([]byte(nil), line...), '\n'))
+	return err
+}
+
+// rewriteSessionID replaces params.id, the pool session id carried by
+// submits and keepalives.
+func rewriteSessionID(params json.RawMessage, id string) json.RawMessage {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(params, &fields); err != nil {
+		return params
+	}
+	if _, ok := fields["id"]; !ok {
+		return params
+	}
+	fields["id"], _ = json.Marshal(id)
+	rewritten, err := json.Marshal(fields)
+	if err != nil {
+		return params
+	}
+	return rewritten
+}
+
+func errorObject(text string) json.RawMessage {
+	data, _ := json.Marshal(map[string]interface{}{"code": -1, "message": text})
+	return data
+}
+
+func writeLine(conn net.Conn, msg message) error {
+	line, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = conn.Write(append(line, '\n'))
+	return err
+}
+
+func newScanner(conn net.Conn) *bufio.Scanner {
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 4096), maxLineSize)
+	return scanner
+}
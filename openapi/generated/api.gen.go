@@ -4,6 +4,7 @@
 package generated
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -24,10 +25,24 @@ type Error struct {
 
 // Health defines model for Health.
 type Health struct {
+	// Checks Per-component results, only in a detailed check.
+	Checks *[]HealthCheck `json:"checks,omitempty"`
+
+	// PoolProbe Result of resolving and dialing the active pool, independent of xmrig.
+	PoolProbe *XMRigPoolProbe `json:"pool_probe,omitempty"`
+
+	// Status ok, or degraded when a detailed check failed.
 	Status string    `json:"status"`
 	Time   time.Time `json:"time"`
 }
 
+// HealthCheck defines model for HealthCheck.
+type HealthCheck struct {
+	Message *string `json:"message,omitempty"`
+	Name    string  `json:"name"`
+	Status  string  `json:"status"`
+}
+
 // Metrics defines model for Metrics.
 type Metrics struct {
 	CpuTemp    string    `json:"cpu_temp"`
@@ -47,34 +62,678 @@ type Specs struct {
 	Threads     int32  `json:"threads"`
 }
 
+// XMRigBenchmark defines model for XMRigBenchmark.
+type XMRigBenchmark struct {
+	Algo *string `json:"algo,omitempty"`
+
+	// Config Effective config of a benchmark run.
+	Config  XMRigBenchmarkConfig `json:"config"`
+	EndTime *time.Time           `json:"end_time,omitempty"`
+	Error   *string              `json:"error,omitempty"`
+
+	// HashSum xmrig's hash sum of the run, equal across nodes for the same size and algo.
+	HashSum *string `json:"hash_sum,omitempty"`
+
+	// HashrateHs Average hashrate over the whole run.
+	HashrateHs *float64 `json:"hashrate_hs,omitempty"`
+	Id         string   `json:"id"`
+
+	// PowerW Average CPU package power sampled during the run.
+	PowerW *float64 `json:"power_w,omitempty"`
+
+	// Proposed True when the run used a proposed config rather than the current one.
+	Proposed bool `json:"proposed"`
+
+	// Seconds Benchmark duration reported by xmrig.
+	Seconds   *float64  `json:"seconds,omitempty"`
+	Size      string    `json:"size"`
+	Specs     *Specs    `json:"specs,omitempty"`
+	StartTime time.Time `json:"start_time"`
+
+	// Status pending, running, done, failed or invalid. An invalid run was refused or cut short because a thermal or idle hold or a thermal throttle would have skewed its result.
+	Status   string   `json:"status"`
+	TempEndC *float64 `json:"temp_end_c,omitempty"`
+
+	// TempMaxC Highest CPU temperature sampled during the run.
+	TempMaxC   *float64 `json:"temp_max_c,omitempty"`
+	TempStartC *float64 `json:"temp_start_c,omitempty"`
+}
+
+// XMRigBenchmarkConfig Effective config of a benchmark run.
+type XMRigBenchmarkConfig struct {
+	Affinity []int32 `json:"affinity"`
+
+	// Args Extra xmrig args, only applied when benchmarking the current config.
+	Args         []string `json:"args"`
+	HugePages    bool     `json:"huge_pages"`
+	HugePagesJit bool     `json:"huge_pages_jit"`
+	InitThreads  int32    `json:"init_threads"`
+	OneGbPages   bool     `json:"one_gb_pages"`
+	Priority     int32    `json:"priority"`
+	RandomxMode  string   `json:"randomx_mode"`
+
+	// Threads 0 when xmrig picked the thread count.
+	Threads int32 `json:"threads"`
+	Wrmsr   bool  `json:"wrmsr"`
+	Yield   bool  `json:"yield"`
+}
+
+// XMRigBenchmarkList defines model for XMRigBenchmarkList.
+type XMRigBenchmarkList struct {
+	Benchmarks []XMRigBenchmark `json:"benchmarks"`
+}
+
+// XMRigBenchmarkRequest defines model for XMRigBenchmarkRequest.
+type XMRigBenchmarkRequest struct {
+	// Config Proposed changes to the configured CPU and RandomX settings; omitted fields keep the configured value.
+	Config *XMRigMinerOverrides `json:"config,omitempty"`
+
+	// Size Number of hashes, 1M or 10M; defaults to 1M.
+	Size *string `json:"size,omitempty"`
+}
+
+// XMRigBinary The xmrig executable as reported by xmrig --version.
+type XMRigBinary struct {
+	BuiltOn    *string   `json:"built_on,omitempty"`
+	Compiler   *string   `json:"compiler,omitempty"`
+	DetectedAt time.Time `json:"detected_at"`
+
+	// Features CPU features xmrig was built for.
+	Features []string `json:"features"`
+
+	// Hwloc True when xmrig was built with hwloc.
+	Hwloc        bool    `json:"hwloc"`
+	HwlocVersion *string `json:"hwloc_version,omitempty"`
+	Libuv        *string `json:"libuv,omitempty"`
+
+	// MinVersion Oldest version grid-node starts, when configured.
+	MinVersion *string `json:"min_version,omitempty"`
+	Path       string  `json:"path"`
+
+	// Pinned True when sha256 matched one of the allowed hashes.
+	Pinned bool `json:"pinned"`
+
+	// Sha256 SHA-256 of the binary, taken before the last launch attempt. Only set when allowed hashes are configured.
+	Sha256 *string `json:"sha256,omitempty"`
+
+	// Tls True when xmrig was built with TLS support.
+	Tls        bool    `json:"tls"`
+	TlsVersion *string `json:"tls_version,omitempty"`
+
+	// Version Empty when the version could not be detected.
+	Version string `json:"version"`
+}
+
+// XMRigCPUTopology defines model for XMRigCPUTopology.
+type XMRigCPUTopology struct {
+	Cores    int32 `json:"cores"`
+	Cpus     int32 `json:"cpus"`
+	L3Bytes  int64 `json:"l3_bytes"`
+	Packages int32 `json:"packages"`
+}
+
+// XMRigFailover Pool failover state, present when more than one pool is configured.
+type XMRigFailover struct {
+	// ActiveIndex Position of the active pool in pools; 0 is the primary.
+	ActiveIndex int32 `json:"active_index"`
+
+	// ActivePool Pool xmrig is currently launched with.
+	ActivePool  string     `json:"active_pool"`
+	ActiveSince *time.Time `json:"active_since,omitempty"`
+
+	// Events Most recent pool switches, oldest first.
+	Events []XMRigPoolEvent `json:"events"`
+
+	// Failures Consecutive connection or login failures of the active pool.
+	Failures         int32      `json:"failures"`
+	LastError        *string    `json:"last_error,omitempty"`
+	NextPrimaryRetry *time.Time `json:"next_primary_retry,omitempty"`
+
+	// Pools Configured pools in failover order.
+	Pools []string `json:"pools"`
+
+	// RetryPrimarySeconds How long a fallback pool is used before the primary is retried.
+	RetryPrimarySeconds int64 `json:"retry_primary_seconds"`
+	Switches            int32 `json:"switches"`
+
+	// Threshold Consecutive failures after which the next pool is used.
+	Threshold int32 `json:"threshold"`
+}
+
+// XMRigHashrateBucket defines model for XMRigHashrateBucket.
+type XMRigHashrateBucket struct {
+	AvgHs   float64   `json:"avg_hs"`
+	MaxHs   float64   `json:"max_hs"`
+	MinHs   float64   `json:"min_hs"`
+	Samples int32     `json:"samples"`
+	Start   time.Time `json:"start"`
+}
+
+// XMRigHashrateHistory defines model for XMRigHashrateHistory.
+type XMRigHashrateHistory struct {
+	Buckets []XMRigHashrateBucket `json:"buckets"`
+
+	// From Start of the first bucket.
+	From        time.Time `json:"from"`
+	StepSeconds int64     `json:"step_seconds"`
+	To          time.Time `json:"to"`
+}
+
+// XMRigIdle Idle-only mining state, present in idle mode.
+type XMRigIdle struct {
+	// BusySeconds How long the host must stay busy before xmrig is paused.
+	BusySeconds int64      `json:"busy_seconds"`
+	HeldSince   *time.Time `json:"held_since,omitempty"`
+
+	// Holding True while xmrig is paused because the host is busy.
+	Holding bool `json:"holding"`
+
+	// IdleSeconds How long the host must stay idle before xmrig is continued.
+	IdleSeconds int64 `json:"idle_seconds"`
+
+	// LoadPercent Last measured CPU load of everything but xmrig, in percent of all CPUs.
+	LoadPercent *float64   `json:"load_percent,omitempty"`
+	LoadTime    *time.Time `json:"load_time,omitempty"`
+	Pauses      int32      `json:"pauses"`
+
+	// ThresholdPercent Non-miner load, in percent of all CPUs, at or above which the host counts as busy.
+	ThresholdPercent float64 `json:"threshold_percent"`
+}
+
 // XMRigLogEntry defines model for XMRigLogEntry.
 type XMRigLogEntry struct {
-	Line string    `json:"line"`
-	Time time.Time `json:"time"`
+	Line string `json:"line"`
+
+	// Seq Monotonically increasing sequence number of the line.
+	Seq int64 `json:"seq"`
+
+	// Stream Output stream the line was read from (stdout or stderr).
+	Stream string    `json:"stream"`
+	Time   time.Time `json:"time"`
 }
 
 // XMRigLogs defines model for XMRigLogs.
 type XMRigLogs struct {
-	Count int32           `json:"count"`
-	Logs  []XMRigLogEntry `json:"logs"`
+	Count int32 `json:"count"`
+
+	// HasMore True when more lines matched than were returned.
+	HasMore bool            `json:"has_more"`
+	Logs    []XMRigLogEntry `json:"logs"`
+
+	// NextSeq Cursor to pass as since_seq to continue after this page.
+	NextSeq int64 `json:"next_seq"`
+
+	// OldestSeq Sequence number of the oldest line still buffered; a smaller cursor means lines were missed.
+	OldestSeq int64 `json:"oldest_seq"`
+}
+
+// XMRigMinerOverrides Proposed changes to the configured CPU and RandomX settings; omitted fields keep the configured value.
+type XMRigMinerOverrides struct {
+	// Affinity CPU per thread, -1 for unpinned.
+	Affinity     *[]int32 `json:"affinity,omitempty"`
+	HugePages    *bool    `json:"huge_pages,omitempty"`
+	HugePagesJit *bool    `json:"huge_pages_jit,omitempty"`
+	InitThreads  *int32   `json:"init_threads,omitempty"`
+	OneGbPages   *bool    `json:"one_gb_pages,omitempty"`
+	Priority     *int32   `json:"priority,omitempty"`
+	RandomxMode  *string  `json:"randomx_mode,omitempty"`
+
+	// Threads Mining threads; 0 lets xmrig decide. Drops a configured affinity of a different length.
+	Threads *int32 `json:"threads,omitempty"`
+	Wrmsr   *bool  `json:"wrmsr,omitempty"`
+	Yield   *bool  `json:"yield,omitempty"`
+}
+
+// XMRigPoolEvent defines model for XMRigPoolEvent.
+type XMRigPoolEvent struct {
+	// Error Last error seen on the pool that was left.
+	Error    *string `json:"error,omitempty"`
+	Failures int32   `json:"failures"`
+	From     string  `json:"from"`
+
+	// Reason failures, or retry_primary when moving back to the primary pool.
+	Reason string    `json:"reason"`
+	Time   time.Time `json:"time"`
+	To     string    `json:"to"`
+}
+
+// XMRigPoolProbe Result of resolving and dialing the active pool, independent of xmrig.
+type XMRigPoolProbe struct {
+	// Addresses Addresses the pool host resolved to, including /etc/hosts entries.
+	Addresses           []string `json:"addresses"`
+	ConnectMs           *float64 `json:"connect_ms,omitempty"`
+	ConsecutiveFailures int32    `json:"consecutive_failures"`
+	DnsMs               *float64 `json:"dns_ms,omitempty"`
+	Error               *string  `json:"error,omitempty"`
+
+	// LastError Most recent failure as stage and message, kept after the pool recovers.
+	LastError       *string    `json:"last_error,omitempty"`
+	LastErrorTime   *time.Time `json:"last_error_time,omitempty"`
+	LastSuccessTime *time.Time `json:"last_success_time,omitempty"`
+	Ok              bool       `json:"ok"`
+	Pool            string     `json:"pool"`
+
+	// Stage Step this probe failed at, dns, connect or tls.
+	Stage *string   `json:"stage,omitempty"`
+	Time  time.Time `json:"time"`
+	Tls   bool      `json:"tls"`
+
+	// TlsMs TLS handshake time, only for TLS pools.
+	TlsMs *float64 `json:"tls_ms,omitempty"`
+}
+
+// XMRigProxy Local stratum proxy state, present when xmrig mines through the proxy. Counters come from the stratum traffic itself.
+type XMRigProxy struct {
+	// Address Local address xmrig connects to.
+	Address       string     `json:"address"`
+	Jobs          int64      `json:"jobs"`
+	LastError     *string    `json:"last_error,omitempty"`
+	LastErrorTime *time.Time `json:"last_error_time,omitempty"`
+	LastJobTime   *time.Time `json:"last_job_time,omitempty"`
+
+	// Miners Connected miners.
+	Miners int32 `json:"miners"`
+
+	// Reconnects Upstream reconnects hidden from the miner.
+	Reconnects     int64 `json:"reconnects"`
+	SharesAccepted int64 `json:"shares_accepted"`
+
+	// SharesRejected Shares rejected by the pool or while the upstream was reconnecting.
+	SharesRejected  int64 `json:"shares_rejected"`
+	SharesSubmitted int64 `json:"shares_submitted"`
+
+	// Upstream Pool the proxy forwards to.
+	Upstream          string `json:"upstream"`
+	UpstreamConnected bool   `json:"upstream_connected"`
+}
+
+// XMRigRandomX RandomX readiness parsed from the startup lines of the running xmrig, present once the first of them was logged.
+type XMRigRandomX struct {
+	Algo *string `json:"algo,omitempty"`
+
+	// DatasetInitMs Time the last dataset initialisation took.
+	DatasetInitMs *int64 `json:"dataset_init_ms,omitempty"`
+
+	// DatasetMb Memory allocated for the dataset and cache.
+	DatasetMb        *int32     `json:"dataset_mb,omitempty"`
+	DatasetReady     bool       `json:"dataset_ready"`
+	DatasetReadyTime *time.Time `json:"dataset_ready_time,omitempty"`
+
+	// Degraded True when huge pages are unavailable, disabled or only partly used, when 1GB pages are enabled but unavailable, or when the MSR mod failed; each costs a large part of the hashrate.
+	Degraded        bool     `json:"degraded"`
+	DegradedReasons []string `json:"degraded_reasons"`
+
+	// HugePages xmrig's HUGE PAGES banner value (supported, unavailable, disabled or permission granted).
+	HugePages          *string `json:"huge_pages,omitempty"`
+	HugePagesAllocated *int32  `json:"huge_pages_allocated,omitempty"`
+
+	// HugePagesPercent Share of the dataset and cache pages backed by huge pages.
+	HugePagesPercent *float64 `json:"huge_pages_percent,omitempty"`
+	HugePagesTotal   *int32   `json:"huge_pages_total,omitempty"`
+
+	// Initializing True while the dataset is being built, at startup and after a seed change.
+	Initializing bool   `json:"initializing"`
+	Mode         string `json:"mode"`
+
+	// Msr ok or failed; omitted when xmrig did not apply an MSR preset.
+	Msr       *string `json:"msr,omitempty"`
+	MsrError  *string `json:"msr_error,omitempty"`
+	MsrPreset *string `json:"msr_preset,omitempty"`
+
+	// OneGbPages xmrig's 1GB PAGES banner value (supported, unavailable or disabled).
+	OneGbPages *string `json:"one_gb_pages,omitempty"`
+}
+
+// XMRigResourceHistory defines model for XMRigResourceHistory.
+type XMRigResourceHistory struct {
+	// IntervalSeconds Time between samples.
+	IntervalSeconds int64            `json:"interval_seconds"`
+	Samples         []XMRigResources `json:"samples"`
+}
+
+// XMRigResources One sample of the xmrig process from /proc, present while xmrig runs.
+type XMRigResources struct {
+	// CpuPercent CPU use since the previous sample, 100 per fully used core; omitted for the first sample of a process.
+	CpuPercent *float64 `json:"cpu_percent,omitempty"`
+
+	// InvoluntaryCtxSwitches Times a thread was preempted; a fast rise means xmrig competes for its cores.
+	InvoluntaryCtxSwitches int64 `json:"involuntary_ctx_switches"`
+	Nice                   int32 `json:"nice"`
+	PeakRssBytes           int64 `json:"peak_rss_bytes"`
+	Pid                    int32 `json:"pid"`
+
+	// ReadBytes Bytes read from storage, omitted when /proc/<pid>/io is unreadable.
+	ReadBytes *int64 `json:"read_bytes,omitempty"`
+	RssBytes  int64  `json:"rss_bytes"`
+
+	// SystemSeconds CPU time spent in the kernel since xmrig started.
+	SystemSeconds float64 `json:"system_seconds"`
+
+	// Threads Threads of the xmrig process, including non-mining ones.
+	Threads int32     `json:"threads"`
+	Time    time.Time `json:"time"`
+
+	// UserSeconds CPU time spent in user mode since xmrig started.
+	UserSeconds          float64 `json:"user_seconds"`
+	VoluntaryCtxSwitches int64   `json:"voluntary_ctx_switches"`
+
+	// WriteBytes Bytes written to storage, omitted when /proc/<pid>/io is unreadable.
+	WriteBytes *int64 `json:"write_bytes,omitempty"`
+}
+
+// XMRigSchedule defines model for XMRigSchedule.
+type XMRigSchedule struct {
+	ActiveSince *time.Time `json:"active_since,omitempty"`
+	ActiveUntil *time.Time `json:"active_until,omitempty"`
+
+	// ActiveWindow Name of the window in effect, omitted outside every window.
+	ActiveWindow *string `json:"active_window,omitempty"`
+
+	// Args Extra xmrig args of the profile in effect.
+	Args []string `json:"args"`
+
+	// Enabled False when no schedule is configured and xmrig mines around the clock.
+	Enabled bool `json:"enabled"`
+
+	// LastTransition When the schedule last started, stopped or re-profiled xmrig.
+	LastTransition *time.Time `json:"last_transition,omitempty"`
+
+	// Mining Whether the schedule currently wants xmrig to mine.
+	Mining         bool       `json:"mining"`
+	NextMining     *bool      `json:"next_mining,omitempty"`
+	NextTransition *time.Time `json:"next_transition,omitempty"`
+
+	// NextWindow Window in effect after the next transition, omitted when it leads outside every window.
+	NextWindow *string               `json:"next_window,omitempty"`
+	Timezone   *string               `json:"timezone,omitempty"`
+	Windows    []XMRigScheduleWindow `json:"windows"`
+}
+
+// XMRigScheduleWindow defines model for XMRigScheduleWindow.
+type XMRigScheduleWindow struct {
+	Args            []string `json:"args"`
+	DurationSeconds int64    `json:"duration_seconds"`
+	Mine            bool     `json:"mine"`
+	Name            string   `json:"name"`
+	Spec            string   `json:"spec"`
 }
 
 // XMRigStatus defines model for XMRigStatus.
 type XMRigStatus struct {
-	HashrateHs    float64    `json:"hashrate_hs"`
-	LastError     *string    `json:"last_error,omitempty"`
-	LastExitTime  *time.Time `json:"last_exit_time,omitempty"`
-	LastLogTime   *time.Time `json:"last_log_time,omitempty"`
-	LastStartTime *time.Time `json:"last_start_time,omitempty"`
-	Running       bool       `json:"running"`
+	// BackoffMs Delay applied before the pending or most recent automatic restart.
+	BackoffMs int64           `json:"backoff_ms"`
+	Benchmark *XMRigBenchmark `json:"benchmark,omitempty"`
+
+	// Binary The xmrig executable as reported by xmrig --version.
+	Binary      *XMRigBinary `json:"binary,omitempty"`
+	BlockHeight *int64       `json:"block_height,omitempty"`
+
+	// CrashLooping True when xmrig exited too often within the crash-loop window.
+	CrashLooping bool `json:"crash_looping"`
+
+	// DesiredState State requested by the operator (running, paused or stopped).
+	DesiredState string  `json:"desired_state"`
+	Difficulty   *int64  `json:"difficulty,omitempty"`
+	ExitCode     *int32  `json:"exit_code,omitempty"`
+	ExitSignal   *string `json:"exit_signal,omitempty"`
+
+	// Failover Pool failover state, present when more than one pool is configured.
+	Failover *XMRigFailover `json:"failover,omitempty"`
+
+	// Hashrate15mHs 15m average hashrate in H/s, omitted while xmrig reports n/a.
+	Hashrate15mHs *float64 `json:"hashrate_15m_hs,omitempty"`
+
+	// Hashrate60sHs 60s average hashrate in H/s, omitted while xmrig reports n/a.
+	Hashrate60sHs *float64 `json:"hashrate_60s_hs,omitempty"`
+
+	// HashrateHs 10s average hashrate in H/s; 0 while xmrig reports n/a.
+	HashrateHs float64 `json:"hashrate_hs"`
+
+	// HashrateMaxHs Highest 10s hashrate in H/s since xmrig started.
+	HashrateMaxHs *float64 `json:"hashrate_max_hs,omitempty"`
+
+	// Idle Idle-only mining state, present in idle mode.
+	Idle         *XMRigIdle `json:"idle,omitempty"`
+	LastError    *string    `json:"last_error,omitempty"`
+	LastExitTime *time.Time `json:"last_exit_time,omitempty"`
+
+	// LastHashrate15mHs Most recent 15m average in H/s, kept after xmrig exits.
+	LastHashrate15mHs *float64 `json:"last_hashrate_15m_hs,omitempty"`
+
+	// LastHashrate15mTime When last_hashrate_15m_hs was observed.
+	LastHashrate15mTime *time.Time `json:"last_hashrate_15m_time,omitempty"`
+	LastJobTime         *time.Time `json:"last_job_time,omitempty"`
+	LastLogTime         *time.Time `json:"last_log_time,omitempty"`
+	LastStartTime       *time.Time `json:"last_start_time,omitempty"`
+
+	// MiningMode always, or idle when xmrig only mines while the host is otherwise idle.
+	MiningMode      *string    `json:"mining_mode,omitempty"`
+	NextRestartTime *time.Time `json:"next_restart_time,omitempty"`
+	Paused          bool       `json:"paused"`
+	Pool            *string    `json:"pool,omitempty"`
+
+	// PoolProbe Result of resolving and dialing the active pool, independent of xmrig.
+	PoolProbe *XMRigPoolProbe `json:"pool_probe,omitempty"`
+
+	// Proxy Local stratum proxy state, present when xmrig mines through the proxy. Counters come from the stratum traffic itself.
+	Proxy *XMRigProxy `json:"proxy,omitempty"`
+
+	// Randomx RandomX readiness parsed from the startup lines of the running xmrig, present once the first of them was logged.
+	Randomx     *XMRigRandomX `json:"randomx,omitempty"`
+	RandomxMode string        `json:"randomx_mode"`
+
+	// Resources One sample of the xmrig process from /proc, present while xmrig runs.
+	Resources *XMRigResources `json:"resources,omitempty"`
+
+	// RestartCount Automatic restarts since grid-node started.
+	RestartCount int32 `json:"restart_count"`
+	Running      bool  `json:"running"`
+
+	// ShareLatencyMs Round-trip time of the last submitted share.
+	ShareLatencyMs *int64 `json:"share_latency_ms,omitempty"`
+	SharesAccepted int64  `json:"shares_accepted"`
+	SharesRejected int64  `json:"shares_rejected"`
+
+	// SharesStale Rejected shares the pool reported as stale or for an expired job.
+	SharesStale int64 `json:"shares_stale"`
+
+	// StatusSource Source of the live figures, api (xmrig HTTP API) or log (stdout parsing).
+	StatusSource string `json:"status_source"`
+
+	// StopReason Why the last xmrig process ended (operator_stop, operator_restart, shutdown, exited, thermal, schedule or failover).
+	StopReason *string `json:"stop_reason,omitempty"`
+
+	// Thermal Thermal guard state, present when the guard is enabled.
+	Thermal *XMRigThermal `json:"thermal,omitempty"`
+
+	// ThreadsHs Per-thread 10s hashrate in H/s, only available from the xmrig API.
+	ThreadsHs     *[]float64        `json:"threads_hs,omitempty"`
+	TuneProfile   *XMRigTuneProfile `json:"tune_profile,omitempty"`
+	UptimeSeconds *int64            `json:"uptime_seconds,omitempty"`
+}
+
+// XMRigThermal Thermal guard state, present when the guard is enabled.
+type XMRigThermal struct {
+	// Action What the guard does when tripped, pause or throttle.
+	Action string `json:"action"`
+
+	// Events Most recent interventions, oldest first.
+	Events        []XMRigThermalEvent `json:"events"`
+	Interventions int32               `json:"interventions"`
+
+	// MinHoldSeconds Minimum time the guard stays tripped before resuming.
+	MinHoldSeconds int64   `json:"min_hold_seconds"`
+	ResumeC        float64 `json:"resume_c"`
+
+	// TempC Last CPU temperature reading, omitted when no sensor is available.
+	TempC    *float64   `json:"temp_c,omitempty"`
+	TempTime *time.Time `json:"temp_time,omitempty"`
+
+	// ThrottleThreads Threads xmrig runs with while throttled.
+	ThrottleThreads *int32     `json:"throttle_threads,omitempty"`
+	TripC           float64    `json:"trip_c"`
+	Tripped         bool       `json:"tripped"`
+	TrippedAt       *time.Time `json:"tripped_at,omitempty"`
+}
+
+// XMRigThermalEvent defines model for XMRigThermalEvent.
+type XMRigThermalEvent struct {
+	// Action pause, throttle or resume.
+	Action  string    `json:"action"`
+	TempC   float64   `json:"temp_c"`
+	Threads *int32    `json:"threads,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// XMRigTune defines model for XMRigTune.
+type XMRigTune struct {
+	Candidates []XMRigTuneCandidate `json:"candidates"`
+	EndTime    *time.Time           `json:"end_time,omitempty"`
+	Error      *string              `json:"error,omitempty"`
+	Id         string               `json:"id"`
+	MaxTempC   *float64             `json:"max_temp_c,omitempty"`
+	Objective  string               `json:"objective"`
+	Profile    *XMRigTuneProfile    `json:"profile,omitempty"`
+	Size       string               `json:"size"`
+
+	// Stage Stage being swept while running.
+	Stage     *string   `json:"stage,omitempty"`
+	StartTime time.Time `json:"start_time"`
+
+	// Status Tune job status; pending until mining stops for the sweep, running while candidates are benchmarked, done once the winner was applied, failed when the sweep was stopped, ran past the tune timeout or no candidate qualified.
+	Status   string           `json:"status"`
+	Topology XMRigCPUTopology `json:"topology"`
+}
+
+// XMRigTuneCandidate defines model for XMRigTuneCandidate.
+type XMRigTuneCandidate struct {
+	BenchmarkId string `json:"benchmark_id"`
+
+	// Config Effective config of a benchmark run.
+	Config         XMRigBenchmarkConfig `json:"config"`
+	Eligible       bool                 `json:"eligible"`
+	HashesPerJoule *float64             `json:"hashes_per_joule,omitempty"`
+	HashrateHs     *float64             `json:"hashrate_hs,omitempty"`
+	Name           string               `json:"name"`
+	PowerW         *float64             `json:"power_w,omitempty"`
+
+	// Reason Why the candidate is not eligible.
+	Reason *string `json:"reason,omitempty"`
+
+	// Skipped A thermal or idle hold stopped the run; the sweep moved on once it was released.
+	Skipped bool `json:"skipped"`
+
+	// Stage threads, randomx_mode or huge_pages.
+	Stage    string   `json:"stage"`
+	TempMaxC *float64 `json:"temp_max_c,omitempty"`
+}
+
+// XMRigTuneProfile defines model for XMRigTuneProfile.
+type XMRigTuneProfile struct {
+	// Affinity CPU per thread; empty when threads are unpinned.
+	Affinity    []int32  `json:"affinity"`
+	BenchmarkId string   `json:"benchmark_id"`
+	HashrateHs  float64  `json:"hashrate_hs"`
+	HugePages   bool     `json:"huge_pages"`
+	Objective   string   `json:"objective"`
+	OneGbPages  bool     `json:"one_gb_pages"`
+	PowerW      *float64 `json:"power_w,omitempty"`
+	RandomxMode string   `json:"randomx_mode"`
+
+	// Score Hashrate in H/s or hashes per joule, depending on objective.
+	Score    float64   `json:"score"`
+	TempMaxC *float64  `json:"temp_max_c,omitempty"`
+	Threads  int32     `json:"threads"`
+	TunedAt  time.Time `json:"tuned_at"`
+}
+
+// XMRigTuneRequest defines model for XMRigTuneRequest.
+type XMRigTuneRequest struct {
+	// MaxTempC Runs hotter than this are not eligible; defaults to the thermal trip point when the guard is on.
+	MaxTempC *float64 `json:"max_temp_c,omitempty"`
+
+	// Objective hashrate or hashrate_per_watt; defaults to hashrate.
+	Objective *string `json:"objective,omitempty"`
+
+	// RandomxModes RandomX modes to try; defaults to fast and light.
+	RandomxModes *[]string `json:"randomx_modes,omitempty"`
+
+	// Size Benchmark size per candidate, 1M or 10M; defaults to 1M.
+	Size *string `json:"size,omitempty"`
+
+	// Threads Thread counts to try; defaults to half the cores, every core, every logical CPU and what fits in L3.
+	Threads *[]int32 `json:"threads,omitempty"`
+}
+
+// XMRigTuneStatus defines model for XMRigTuneStatus.
+type XMRigTuneStatus struct {
+	Job     *XMRigTune        `json:"job,omitempty"`
+	Profile *XMRigTuneProfile `json:"profile,omitempty"`
+}
+
+// GetHealthParams defines parameters for GetHealth.
+type GetHealthParams struct {
+	// Detailed Also check xmrig and probe results for the pool; a degraded node answers 503.
+	Detailed *bool `form:"detailed,omitempty" json:"detailed,omitempty"`
+}
+
+// GetXmrigBenchmarksParams defines parameters for GetXmrigBenchmarks.
+type GetXmrigBenchmarksParams struct {
+	// Limit Return at most this many runs.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetXmrigHashrateHistoryParams defines parameters for GetXmrigHashrateHistory.
+type GetXmrigHashrateHistoryParams struct {
+	// From Start of the range; defaults to one hour before `to`.
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To End of the range; defaults to now.
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Step Bucket width as a duration such as 30s or 5m; defaults to a width giving about 120 buckets.
+	Step *string `form:"step,omitempty" json:"step,omitempty"`
 }
 
 // GetXmrigLogsParams defines parameters for GetXmrigLogs.
 type GetXmrigLogsParams struct {
-	// N Number of most recent log lines to return (max 250).
+	// N Maximum number of log lines to return (max 10000).
 	N *int `form:"n,omitempty" json:"n,omitempty"`
+
+	// SinceSeq Only return lines with a sequence number greater than this.
+	SinceSeq *int64 `form:"since_seq,omitempty" json:"since_seq,omitempty"`
+
+	// Since Only return lines recorded at or after this time.
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Only return lines recorded before this time.
+	Until *time.Time `form:"until,omitempty" json:"until,omitempty"`
+
+	// History Read the persistent on-disk log instead of the in-memory buffer.
+	History *bool `form:"history,omitempty" json:"history,omitempty"`
+}
+
+// StreamXmrigLogsParams defines parameters for StreamXmrigLogs.
+type StreamXmrigLogsParams struct {
+	// Backlog Number of recent log lines to send before live lines (max 10000).
+	Backlog *int `form:"backlog,omitempty" json:"backlog,omitempty"`
+}
+
+// GetXmrigResourceHistoryParams defines parameters for GetXmrigResourceHistory.
+type GetXmrigResourceHistoryParams struct {
+	// Since Only return samples taken after this time.
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Limit Return at most this many of the newest samples.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// BenchmarkXmrigJSONRequestBody defines body for BenchmarkXmrig for application/json ContentType.
+type BenchmarkXmrigJSONRequestBody = XMRigBenchmarkRequest
+
+// TuneXmrigJSONRequestBody defines body for TuneXmrig for application/json ContentType.
+type TuneXmrigJSONRequestBody = XMRigTuneRequest
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -149,7 +808,7 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 // The interface specification for the client above.
 type ClientInterface interface {
 	// GetHealth request
-	GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+	GetHealth(ctx context.Context, params *GetHealthParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// GetMetrics request
 	GetMetrics(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
@@ -160,12 +819,52 @@ type ClientInterface interface {
 	// GetXmrigStatus request
 	GetXmrigStatus(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// BenchmarkXmrigWithBody request with any body
+	BenchmarkXmrigWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	BenchmarkXmrig(ctx context.Context, body BenchmarkXmrigJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetXmrigBenchmarks request
+	GetXmrigBenchmarks(ctx context.Context, params *GetXmrigBenchmarksParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetXmrigHashrateHistory request
+	GetXmrigHashrateHistory(ctx context.Context, params *GetXmrigHashrateHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetXmrigLogs request
 	GetXmrigLogs(ctx context.Context, params *GetXmrigLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StreamXmrigLogs request
+	StreamXmrigLogs(ctx context.Context, params *StreamXmrigLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PauseXmrig request
+	PauseXmrig(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetXmrigResourceHistory request
+	GetXmrigResourceHistory(ctx context.Context, params *GetXmrigResourceHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RestartXmrig request
+	RestartXmrig(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetXmrigSchedule request
+	GetXmrigSchedule(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StartXmrig request
+	StartXmrig(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StopXmrig request
+	StopXmrig(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetXmrigTune request
+	GetXmrigTune(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TuneXmrigWithBody request with any body
+	TuneXmrigWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	TuneXmrig(ctx context.Context, body TuneXmrigJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
-func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetHealthRequest(c.Server)
+func (c *Client) GetHealth(ctx context.Context, params *GetHealthParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHealthRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -212,8 +911,8 @@ func (c *Client) GetXmrigStatus(ctx context.Context, reqEditors ...RequestEditor
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetXmrigLogs(ctx context.Context, params *GetXmrigLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetXmrigLogsRequest(c.Server, params)
+func (c *Client) BenchmarkXmrigWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBenchmarkXmrigRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -224,116 +923,176 @@ func (c *Client) GetXmrigLogs(ctx context.Context, params *GetXmrigLogsParams, r
 	return c.Client.Do(req)
 }
 
-// NewGetHealthRequest generates requests for GetHealth
-func NewGetHealthRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) BenchmarkXmrig(ctx context.Context, body BenchmarkXmrigJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBenchmarkXmrigRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/health")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) GetXmrigBenchmarks(ctx context.Context, params *GetXmrigBenchmarksParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetXmrigBenchmarksRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewGetMetricsRequest generates requests for GetMetrics
-func NewGetMetricsRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) GetXmrigHashrateHistory(ctx context.Context, params *GetXmrigHashrateHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetXmrigHashrateHistoryRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/metrics")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) GetXmrigLogs(ctx context.Context, params *GetXmrigLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetXmrigLogsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewGetSpecsRequest generates requests for GetSpecs
-func NewGetSpecsRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) StreamXmrigLogs(ctx context.Context, params *StreamXmrigLogsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStreamXmrigLogsRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/specs")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PauseXmrig(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPauseXmrigRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewGetXmrigStatusRequest generates requests for GetXmrigStatus
-func NewGetXmrigStatusRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) GetXmrigResourceHistory(ctx context.Context, params *GetXmrigResourceHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetXmrigResourceHistoryRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	operationPath := fmt.Sprintf("/xmrig")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+func (c *Client) RestartXmrig(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRestartXmrigRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) GetXmrigSchedule(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetXmrigScheduleRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) StartXmrig(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStartXmrigRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) StopXmrig(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStopXmrigRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	return req, nil
+func (c *Client) GetXmrigTune(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetXmrigTuneRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewGetXmrigLogsRequest generates requests for GetXmrigLogs
-func NewGetXmrigLogsRequest(server string, params *GetXmrigLogsParams) (*http.Request, error) {
+func (c *Client) TuneXmrigWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTuneXmrigRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TuneXmrig(ctx context.Context, body TuneXmrigJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTuneXmrigRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetHealthRequest generates requests for GetHealth
+func NewGetHealthRequest(server string, params *GetHealthParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -341,7 +1100,7 @@ func NewGetXmrigLogsRequest(server string, params *GetXmrigLogsParams) (*http.Re
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/xmrig/logs")
+	operationPath := fmt.Sprintf("/health")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -354,9 +1113,9 @@ func NewGetXmrigLogsRequest(server string, params *GetXmrigLogsParams) (*http.Re
 	if params != nil {
 		queryValues := queryURL.Query()
 
-		if params.N != nil {
+		if params.Detailed != nil {
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "n", runtime.ParamLocationQuery, *params.N); err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "detailed", runtime.ParamLocationQuery, *params.Detailed); err != nil {
 				return nil, err
 			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
@@ -381,238 +1140,1783 @@ func NewGetXmrigLogsRequest(server string, params *GetXmrigLogsParams) (*http.Re
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
+// NewGetMetricsRequest generates requests for GetMetrics
+func NewGetMetricsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return nil
-}
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+	operationPath := fmt.Sprintf("/metrics")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
-}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
+
+	return req, nil
 }
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// GetHealthWithResponse request
-	GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error)
+// NewGetSpecsRequest generates requests for GetSpecs
+func NewGetSpecsRequest(server string) (*http.Request, error) {
+	var err error
 
-	// GetMetricsWithResponse request
-	GetMetricsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetMetricsResponse, error)
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-	// GetSpecsWithResponse request
-	GetSpecsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSpecsResponse, error)
+	operationPath := fmt.Sprintf("/specs")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	// GetXmrigStatusWithResponse request
-	GetXmrigStatusWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetXmrigStatusResponse, error)
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-	// GetXmrigLogsWithResponse request
-	GetXmrigLogsWithResponse(ctx context.Context, params *GetXmrigLogsParams, reqEditors ...RequestEditorFn) (*GetXmrigLogsResponse, error)
-}
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
 
-type GetHealthResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *Health
+	return req, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetHealthResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// NewGetXmrigStatusRequest generates requests for GetXmrigStatus
+func NewGetXmrigStatusRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetHealthResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	operationPath := fmt.Sprintf("/xmrig")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
 	}
-	return 0
-}
 
-type GetMetricsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *Metrics
-}
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-// Status returns HTTPResponse.Status
-func (r GetMetricsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+
+	return req, nil
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetMetricsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// NewBenchmarkXmrigRequest calls the generic BenchmarkXmrig builder with application/json body
+func NewBenchmarkXmrigRequest(server string, body BenchmarkXmrigJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	bodyReader = bytes.NewReader(buf)
+	return NewBenchmarkXmrigRequestWithBody(server, "application/json", bodyReader)
 }
 
-type GetSpecsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *Specs
-	JSON500      *Error
-}
+// NewBenchmarkXmrigRequestWithBody generates requests for BenchmarkXmrig with any type of body
+func NewBenchmarkXmrigRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-// Status returns HTTPResponse.Status
-func (r GetSpecsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetSpecsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	operationPath := fmt.Sprintf("/xmrig/benchmark")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
 	}
-	return 0
-}
 
-type GetXmrigStatusResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *XMRigStatus
-}
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
 
-// Status returns HTTPResponse.Status
-func (r GetXmrigStatusResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetXmrigStatusResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
+// NewGetXmrigBenchmarksRequest generates requests for GetXmrigBenchmarks
+func NewGetXmrigBenchmarksRequest(server string, params *GetXmrigBenchmarksParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/xmrig/benchmarks")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetXmrigHashrateHistoryRequest generates requests for GetXmrigHashrateHistory
+func NewGetXmrigHashrateHistoryRequest(server string, params *GetXmrigHashrateHistoryParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/xmrig/hashrate/history")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Step != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "step", runtime.ParamLocationQuery, *params.Step); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetXmrigLogsRequest generates requests for GetXmrigLogs
+func NewGetXmrigLogsRequest(server string, params *GetXmrigLogsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/xmrig/logs")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.N != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "n", runtime.ParamLocationQuery, *params.N); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.SinceSeq != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since_seq", runtime.ParamLocationQuery, *params.SinceSeq); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Since != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Until != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "until", runtime.ParamLocationQuery, *params.Until); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.History != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "history", runtime.ParamLocationQuery, *params.History); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewStreamXmrigLogsRequest generates requests for StreamXmrigLogs
+func NewStreamXmrigLogsRequest(server string, params *StreamXmrigLogsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/xmrig/logs/stream")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Backlog != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "backlog", runtime.ParamLocationQuery, *params.Backlog); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPauseXmrigRequest generates requests for PauseXmrig
+func NewPauseXmrigRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/xmrig/pause")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetXmrigResourceHistoryRequest generates requests for GetXmrigResourceHistory
+func NewGetXmrigResourceHistoryRequest(server string, params *GetXmrigResourceHistoryParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/xmrig/resources/history")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Since != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRestartXmrigRequest generates requests for RestartXmrig
+func NewRestartXmrigRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/xmrig/restart")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetXmrigScheduleRequest generates requests for GetXmrigSchedule
+func NewGetXmrigScheduleRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/xmrig/schedule")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewStartXmrigRequest generates requests for StartXmrig
+func NewStartXmrigRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/xmrig/start")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewStopXmrigRequest generates requests for StopXmrig
+func NewStopXmrigRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/xmrig/stop")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetXmrigTuneRequest generates requests for GetXmrigTune
+func NewGetXmrigTuneRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/xmrig/tune")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTuneXmrigRequest calls the generic TuneXmrig builder with application/json body
+func NewTuneXmrigRequest(server string, body TuneXmrigJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTuneXmrigRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewTuneXmrigRequestWithBody generates requests for TuneXmrig with any type of body
+func NewTuneXmrigRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/xmrig/tune")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetHealthWithResponse request
+	GetHealthWithResponse(ctx context.Context, params *GetHealthParams, reqEditors ...RequestEditorFn) (*GetHealthResponse, error)
+
+	// GetMetricsWithResponse request
+	GetMetricsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetMetricsResponse, error)
+
+	// GetSpecsWithResponse request
+	GetSpecsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSpecsResponse, error)
+
+	// GetXmrigStatusWithResponse request
+	GetXmrigStatusWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetXmrigStatusResponse, error)
+
+	// BenchmarkXmrigWithBodyWithResponse request with any body
+	BenchmarkXmrigWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BenchmarkXmrigResponse, error)
+
+	BenchmarkXmrigWithResponse(ctx context.Context, body BenchmarkXmrigJSONRequestBody, reqEditors ...RequestEditorFn) (*BenchmarkXmrigResponse, error)
+
+	// GetXmrigBenchmarksWithResponse request
+	GetXmrigBenchmarksWithResponse(ctx context.Context, params *GetXmrigBenchmarksParams, reqEditors ...RequestEditorFn) (*GetXmrigBenchmarksResponse, error)
+
+	// GetXmrigHashrateHistoryWithResponse request
+	GetXmrigHashrateHistoryWithResponse(ctx context.Context, params *GetXmrigHashrateHistoryParams, reqEditors ...RequestEditorFn) (*GetXmrigHashrateHistoryResponse, error)
+
+	// GetXmrigLogsWithResponse request
+	GetXmrigLogsWithResponse(ctx context.Context, params *GetXmrigLogsParams, reqEditors ...RequestEditorFn) (*GetXmrigLogsResponse, error)
+
+	// StreamXmrigLogsWithResponse request
+	StreamXmrigLogsWithResponse(ctx context.Context, params *StreamXmrigLogsParams, reqEditors ...RequestEditorFn) (*StreamXmrigLogsResponse, error)
+
+	// PauseXmrigWithResponse request
+	PauseXmrigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PauseXmrigResponse, error)
+
+	// GetXmrigResourceHistoryWithResponse request
+	GetXmrigResourceHistoryWithResponse(ctx context.Context, params *GetXmrigResourceHistoryParams, reqEditors ...RequestEditorFn) (*GetXmrigResourceHistoryResponse, error)
+
+	// RestartXmrigWithResponse request
+	RestartXmrigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*RestartXmrigResponse, error)
+
+	// GetXmrigScheduleWithResponse request
+	GetXmrigScheduleWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetXmrigScheduleResponse, error)
+
+	// StartXmrigWithResponse request
+	StartXmrigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*StartXmrigResponse, error)
+
+	// StopXmrigWithResponse request
+	StopXmrigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*StopXmrigResponse, error)
+
+	// GetXmrigTuneWithResponse request
+	GetXmrigTuneWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetXmrigTuneResponse, error)
+
+	// TuneXmrigWithBodyWithResponse request with any body
+	TuneXmrigWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TuneXmrigResponse, error)
+
+	TuneXmrigWithResponse(ctx context.Context, body TuneXmrigJSONRequestBody, reqEditors ...RequestEditorFn) (*TuneXmrigResponse, error)
+}
+
+type GetHealthResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Health
+	JSON503      *Health
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHealthResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHealthResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetMetricsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Metrics
+}
+
+// Status returns HTTPResponse.Status
+func (r GetMetricsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetMetricsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSpecsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Specs
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSpecsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSpecsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetXmrigStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *XMRigStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r GetXmrigStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetXmrigStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type BenchmarkXmrigResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *XMRigBenchmark
+	JSON400      *Error
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r BenchmarkXmrigResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r BenchmarkXmrigResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetXmrigBenchmarksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *XMRigBenchmarkList
+	JSON400      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetXmrigBenchmarksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetXmrigBenchmarksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetXmrigHashrateHistoryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *XMRigHashrateHistory
+	JSON400      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetXmrigHashrateHistoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetXmrigHashrateHistoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetXmrigLogsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *XMRigLogs
+	JSON400      *Error
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetXmrigLogsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetXmrigLogsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StreamXmrigLogsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r StreamXmrigLogsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StreamXmrigLogsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PauseXmrigResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *XMRigStatus
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PauseXmrigResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PauseXmrigResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetXmrigResourceHistoryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *XMRigResourceHistory
+	JSON400      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetXmrigResourceHistoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetXmrigResourceHistoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RestartXmrigResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *XMRigStatus
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r RestartXmrigResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RestartXmrigResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetXmrigScheduleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *XMRigSchedule
+}
+
+// Status returns HTTPResponse.Status
+func (r GetXmrigScheduleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetXmrigScheduleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StartXmrigResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *XMRigStatus
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r StartXmrigResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StartXmrigResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StopXmrigResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *XMRigStatus
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r StopXmrigResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StopXmrigResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetXmrigTuneResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *XMRigTuneStatus
+}
+
+// Status returns HTTPResponse.Status
+func (r GetXmrigTuneResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetXmrigTuneResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TuneXmrigResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *XMRigTune
+	JSON400      *Error
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r TuneXmrigResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TuneXmrigResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
-	return 0
+	return 0
+}
+
+// GetHealthWithResponse request returning *GetHealthResponse
+func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, params *GetHealthParams, reqEditors ...RequestEditorFn) (*GetHealthResponse, error) {
+	rsp, err := c.GetHealth(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHealthResponse(rsp)
+}
+
+// GetMetricsWithResponse request returning *GetMetricsResponse
+func (c *ClientWithResponses) GetMetricsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetMetricsResponse, error) {
+	rsp, err := c.GetMetrics(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetMetricsResponse(rsp)
+}
+
+// GetSpecsWithResponse request returning *GetSpecsResponse
+func (c *ClientWithResponses) GetSpecsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSpecsResponse, error) {
+	rsp, err := c.GetSpecs(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSpecsResponse(rsp)
+}
+
+// GetXmrigStatusWithResponse request returning *GetXmrigStatusResponse
+func (c *ClientWithResponses) GetXmrigStatusWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetXmrigStatusResponse, error) {
+	rsp, err := c.GetXmrigStatus(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetXmrigStatusResponse(rsp)
+}
+
+// BenchmarkXmrigWithBodyWithResponse request with arbitrary body returning *BenchmarkXmrigResponse
+func (c *ClientWithResponses) BenchmarkXmrigWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BenchmarkXmrigResponse, error) {
+	rsp, err := c.BenchmarkXmrigWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBenchmarkXmrigResponse(rsp)
+}
+
+func (c *ClientWithResponses) BenchmarkXmrigWithResponse(ctx context.Context, body BenchmarkXmrigJSONRequestBody, reqEditors ...RequestEditorFn) (*BenchmarkXmrigResponse, error) {
+	rsp, err := c.BenchmarkXmrig(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBenchmarkXmrigResponse(rsp)
+}
+
+// GetXmrigBenchmarksWithResponse request returning *GetXmrigBenchmarksResponse
+func (c *ClientWithResponses) GetXmrigBenchmarksWithResponse(ctx context.Context, params *GetXmrigBenchmarksParams, reqEditors ...RequestEditorFn) (*GetXmrigBenchmarksResponse, error) {
+	rsp, err := c.GetXmrigBenchmarks(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetXmrigBenchmarksResponse(rsp)
+}
+
+// GetXmrigHashrateHistoryWithResponse request returning *GetXmrigHashrateHistoryResponse
+func (c *ClientWithResponses) GetXmrigHashrateHistoryWithResponse(ctx context.Context, params *GetXmrigHashrateHistoryParams, reqEditors ...RequestEditorFn) (*GetXmrigHashrateHistoryResponse, error) {
+	rsp, err := c.GetXmrigHashrateHistory(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetXmrigHashrateHistoryResponse(rsp)
+}
+
+// GetXmrigLogsWithResponse request returning *GetXmrigLogsResponse
+func (c *ClientWithResponses) GetXmrigLogsWithResponse(ctx context.Context, params *GetXmrigLogsParams, reqEditors ...RequestEditorFn) (*GetXmrigLogsResponse, error) {
+	rsp, err := c.GetXmrigLogs(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetXmrigLogsResponse(rsp)
+}
+
+// StreamXmrigLogsWithResponse request returning *StreamXmrigLogsResponse
+func (c *ClientWithResponses) StreamXmrigLogsWithResponse(ctx context.Context, params *StreamXmrigLogsParams, reqEditors ...RequestEditorFn) (*StreamXmrigLogsResponse, error) {
+	rsp, err := c.StreamXmrigLogs(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStreamXmrigLogsResponse(rsp)
+}
+
+// PauseXmrigWithResponse request returning *PauseXmrigResponse
+func (c *ClientWithResponses) PauseXmrigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PauseXmrigResponse, error) {
+	rsp, err := c.PauseXmrig(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePauseXmrigResponse(rsp)
+}
+
+// GetXmrigResourceHistoryWithResponse request returning *GetXmrigResourceHistoryResponse
+func (c *ClientWithResponses) GetXmrigResourceHistoryWithResponse(ctx context.Context, params *GetXmrigResourceHistoryParams, reqEditors ...RequestEditorFn) (*GetXmrigResourceHistoryResponse, error) {
+	rsp, err := c.GetXmrigResourceHistory(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetXmrigResourceHistoryResponse(rsp)
+}
+
+// RestartXmrigWithResponse request returning *RestartXmrigResponse
+func (c *ClientWithResponses) RestartXmrigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*RestartXmrigResponse, error) {
+	rsp, err := c.RestartXmrig(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRestartXmrigResponse(rsp)
+}
+
+// GetXmrigScheduleWithResponse request returning *GetXmrigScheduleResponse
+func (c *ClientWithResponses) GetXmrigScheduleWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetXmrigScheduleResponse, error) {
+	rsp, err := c.GetXmrigSchedule(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetXmrigScheduleResponse(rsp)
+}
+
+// StartXmrigWithResponse request returning *StartXmrigResponse
+func (c *ClientWithResponses) StartXmrigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*StartXmrigResponse, error) {
+	rsp, err := c.StartXmrig(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStartXmrigResponse(rsp)
+}
+
+// StopXmrigWithResponse request returning *StopXmrigResponse
+func (c *ClientWithResponses) StopXmrigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*StopXmrigResponse, error) {
+	rsp, err := c.StopXmrig(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStopXmrigResponse(rsp)
+}
+
+// GetXmrigTuneWithResponse request returning *GetXmrigTuneResponse
+func (c *ClientWithResponses) GetXmrigTuneWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetXmrigTuneResponse, error) {
+	rsp, err := c.GetXmrigTune(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetXmrigTuneResponse(rsp)
+}
+
+// TuneXmrigWithBodyWithResponse request with arbitrary body returning *TuneXmrigResponse
+func (c *ClientWithResponses) TuneXmrigWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TuneXmrigResponse, error) {
+	rsp, err := c.TuneXmrigWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTuneXmrigResponse(rsp)
+}
+
+func (c *ClientWithResponses) TuneXmrigWithResponse(ctx context.Context, body TuneXmrigJSONRequestBody, reqEditors ...RequestEditorFn) (*TuneXmrigResponse, error) {
+	rsp, err := c.TuneXmrig(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTuneXmrigResponse(rsp)
+}
+
+// ParseGetHealthResponse parses an HTTP response from a GetHealthWithResponse call
+func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHealthResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Health
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Health
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetMetricsResponse parses an HTTP response from a GetMetricsWithResponse call
+func ParseGetMetricsResponse(rsp *http.Response) (*GetMetricsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetMetricsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Metrics
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSpecsResponse parses an HTTP response from a GetSpecsWithResponse call
+func ParseGetSpecsResponse(rsp *http.Response) (*GetSpecsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSpecsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Specs
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
 }
 
-type GetXmrigLogsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *XMRigLogs
-	JSON400      *Error
+// ParseGetXmrigStatusResponse parses an HTTP response from a GetXmrigStatusWithResponse call
+func ParseGetXmrigStatusResponse(rsp *http.Response) (*GetXmrigStatusResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetXmrigStatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest XMRigStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r GetXmrigLogsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseBenchmarkXmrigResponse parses an HTTP response from a BenchmarkXmrigWithResponse call
+func ParseBenchmarkXmrigResponse(rsp *http.Response) (*BenchmarkXmrigResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+
+	response := &BenchmarkXmrigResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest XMRigBenchmark
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetXmrigLogsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// ParseGetXmrigBenchmarksResponse parses an HTTP response from a GetXmrigBenchmarksWithResponse call
+func ParseGetXmrigBenchmarksResponse(rsp *http.Response) (*GetXmrigBenchmarksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetXmrigBenchmarksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest XMRigBenchmarkList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetXmrigHashrateHistoryResponse parses an HTTP response from a GetXmrigHashrateHistoryWithResponse call
+func ParseGetXmrigHashrateHistoryResponse(rsp *http.Response) (*GetXmrigHashrateHistoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetXmrigHashrateHistoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest XMRigHashrateHistory
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetXmrigLogsResponse parses an HTTP response from a GetXmrigLogsWithResponse call
+func ParseGetXmrigLogsResponse(rsp *http.Response) (*GetXmrigLogsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetXmrigLogsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest XMRigLogs
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseStreamXmrigLogsResponse parses an HTTP response from a StreamXmrigLogsWithResponse call
+func ParseStreamXmrigLogsResponse(rsp *http.Response) (*StreamXmrigLogsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StreamXmrigLogsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePauseXmrigResponse parses an HTTP response from a PauseXmrigWithResponse call
+func ParsePauseXmrigResponse(rsp *http.Response) (*PauseXmrigResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PauseXmrigResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest XMRigStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
 	}
-	return 0
+
+	return response, nil
 }
 
-// GetHealthWithResponse request returning *GetHealthResponse
-func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error) {
-	rsp, err := c.GetHealth(ctx, reqEditors...)
+// ParseGetXmrigResourceHistoryResponse parses an HTTP response from a GetXmrigResourceHistoryWithResponse call
+func ParseGetXmrigResourceHistoryResponse(rsp *http.Response) (*GetXmrigResourceHistoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetHealthResponse(rsp)
-}
 
-// GetMetricsWithResponse request returning *GetMetricsResponse
-func (c *ClientWithResponses) GetMetricsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetMetricsResponse, error) {
-	rsp, err := c.GetMetrics(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetXmrigResourceHistoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetMetricsResponse(rsp)
-}
 
-// GetSpecsWithResponse request returning *GetSpecsResponse
-func (c *ClientWithResponses) GetSpecsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSpecsResponse, error) {
-	rsp, err := c.GetSpecs(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest XMRigResourceHistory
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	}
-	return ParseGetSpecsResponse(rsp)
+
+	return response, nil
 }
 
-// GetXmrigStatusWithResponse request returning *GetXmrigStatusResponse
-func (c *ClientWithResponses) GetXmrigStatusWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetXmrigStatusResponse, error) {
-	rsp, err := c.GetXmrigStatus(ctx, reqEditors...)
+// ParseRestartXmrigResponse parses an HTTP response from a RestartXmrigWithResponse call
+func ParseRestartXmrigResponse(rsp *http.Response) (*RestartXmrigResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseGetXmrigStatusResponse(rsp)
-}
 
-// GetXmrigLogsWithResponse request returning *GetXmrigLogsResponse
-func (c *ClientWithResponses) GetXmrigLogsWithResponse(ctx context.Context, params *GetXmrigLogsParams, reqEditors ...RequestEditorFn) (*GetXmrigLogsResponse, error) {
-	rsp, err := c.GetXmrigLogs(ctx, params, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &RestartXmrigResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseGetXmrigLogsResponse(rsp)
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest XMRigStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
 }
 
-// ParseGetHealthResponse parses an HTTP response from a GetHealthWithResponse call
-func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
+// ParseGetXmrigScheduleResponse parses an HTTP response from a GetXmrigScheduleWithResponse call
+func ParseGetXmrigScheduleResponse(rsp *http.Response) (*GetXmrigScheduleResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetHealthResponse{
+	response := &GetXmrigScheduleResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Health
+		var dest XMRigSchedule
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -623,53 +2927,74 @@ func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
 	return response, nil
 }
 
-// ParseGetMetricsResponse parses an HTTP response from a GetMetricsWithResponse call
-func ParseGetMetricsResponse(rsp *http.Response) (*GetMetricsResponse, error) {
+// ParseStartXmrigResponse parses an HTTP response from a StartXmrigWithResponse call
+func ParseStartXmrigResponse(rsp *http.Response) (*StartXmrigResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetMetricsResponse{
+	response := &StartXmrigResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Metrics
+		var dest XMRigStatus
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
 	}
 
 	return response, nil
 }
 
-// ParseGetSpecsResponse parses an HTTP response from a GetSpecsWithResponse call
-func ParseGetSpecsResponse(rsp *http.Response) (*GetSpecsResponse, error) {
+// ParseStopXmrigResponse parses an HTTP response from a StopXmrigWithResponse call
+func ParseStopXmrigResponse(rsp *http.Response) (*StopXmrigResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetSpecsResponse{
+	response := &StopXmrigResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Specs
+		var dest XMRigStatus
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -682,22 +3007,22 @@ func ParseGetSpecsResponse(rsp *http.Response) (*GetSpecsResponse, error) {
 	return response, nil
 }
 
-// ParseGetXmrigStatusResponse parses an HTTP response from a GetXmrigStatusWithResponse call
-func ParseGetXmrigStatusResponse(rsp *http.Response) (*GetXmrigStatusResponse, error) {
+// ParseGetXmrigTuneResponse parses an HTTP response from a GetXmrigTuneWithResponse call
+func ParseGetXmrigTuneResponse(rsp *http.Response) (*GetXmrigTuneResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetXmrigStatusResponse{
+	response := &GetXmrigTuneResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest XMRigStatus
+		var dest XMRigTuneStatus
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -708,26 +3033,26 @@ func ParseGetXmrigStatusResponse(rsp *http.Response) (*GetXmrigStatusResponse, e
 	return response, nil
 }
 
-// ParseGetXmrigLogsResponse parses an HTTP response from a GetXmrigLogsWithResponse call
-func ParseGetXmrigLogsResponse(rsp *http.Response) (*GetXmrigLogsResponse, error) {
+// ParseTuneXmrigResponse parses an HTTP response from a TuneXmrigWithResponse call
+func ParseTuneXmrigResponse(rsp *http.Response) (*TuneXmrigResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetXmrigLogsResponse{
+	response := &TuneXmrigResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest XMRigLogs
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest XMRigTune
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON200 = &dest
+		response.JSON202 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
 		var dest Error
@@ -736,6 +3061,20 @@ func ParseGetXmrigLogsResponse(rsp *http.Response) (*GetXmrigLogsResponse, error
 		}
 		response.JSON400 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
 	}
 
 	return response, nil
@@ -745,7 +3084,7 @@ func ParseGetXmrigLogsResponse(rsp *http.Response) (*GetXmrigLogsResponse, error
 type ServerInterface interface {
 	// Health check
 	// (GET /health)
-	GetHealth(ctx echo.Context) error
+	GetHealth(ctx echo.Context, params GetHealthParams) error
 	// Read live CPU metrics
 	// (GET /metrics)
 	GetMetrics(ctx echo.Context) error
@@ -755,9 +3094,45 @@ type ServerInterface interface {
 	// Read XMRig status
 	// (GET /xmrig)
 	GetXmrigStatus(ctx echo.Context) error
+	// Run an XMRig benchmark
+	// (POST /xmrig/benchmark)
+	BenchmarkXmrig(ctx echo.Context) error
+	// List XMRig benchmarks
+	// (GET /xmrig/benchmarks)
+	GetXmrigBenchmarks(ctx echo.Context, params GetXmrigBenchmarksParams) error
+	// Read XMRig hashrate history
+	// (GET /xmrig/hashrate/history)
+	GetXmrigHashrateHistory(ctx echo.Context, params GetXmrigHashrateHistoryParams) error
 	// Read recent XMRig logs
 	// (GET /xmrig/logs)
 	GetXmrigLogs(ctx echo.Context, params GetXmrigLogsParams) error
+	// Stream XMRig logs
+	// (GET /xmrig/logs/stream)
+	StreamXmrigLogs(ctx echo.Context, params StreamXmrigLogsParams) error
+	// Pause XMRig
+	// (POST /xmrig/pause)
+	PauseXmrig(ctx echo.Context) error
+	// Read XMRig resource usage history
+	// (GET /xmrig/resources/history)
+	GetXmrigResourceHistory(ctx echo.Context, params GetXmrigResourceHistoryParams) error
+	// Restart XMRig
+	// (POST /xmrig/restart)
+	RestartXmrig(ctx echo.Context) error
+	// Read the XMRig mining schedule
+	// (GET /xmrig/schedule)
+	GetXmrigSchedule(ctx echo.Context) error
+	// Start XMRig
+	// (POST /xmrig/start)
+	StartXmrig(ctx echo.Context) error
+	// Stop XMRig
+	// (POST /xmrig/stop)
+	StopXmrig(ctx echo.Context) error
+	// Read XMRig auto-tune state
+	// (GET /xmrig/tune)
+	GetXmrigTune(ctx echo.Context) error
+	// Auto-tune XMRig
+	// (POST /xmrig/tune)
+	TuneXmrig(ctx echo.Context) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -769,8 +3144,17 @@ type ServerInterfaceWrapper struct {
 func (w *ServerInterfaceWrapper) GetHealth(ctx echo.Context) error {
 	var err error
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetHealthParams
+	// ------------- Optional query parameter "detailed" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "detailed", ctx.QueryParams(), &params.Detailed)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter detailed: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetHealth(ctx)
+	err = w.Handler.GetHealth(ctx, params)
 	return err
 }
 
@@ -801,6 +3185,65 @@ func (w *ServerInterfaceWrapper) GetXmrigStatus(ctx echo.Context) error {
 	return err
 }
 
+// BenchmarkXmrig converts echo context to params.
+func (w *ServerInterfaceWrapper) BenchmarkXmrig(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.BenchmarkXmrig(ctx)
+	return err
+}
+
+// GetXmrigBenchmarks converts echo context to params.
+func (w *ServerInterfaceWrapper) GetXmrigBenchmarks(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetXmrigBenchmarksParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetXmrigBenchmarks(ctx, params)
+	return err
+}
+
+// GetXmrigHashrateHistory converts echo context to params.
+func (w *ServerInterfaceWrapper) GetXmrigHashrateHistory(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetXmrigHashrateHistoryParams
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// ------------- Optional query parameter "step" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "step", ctx.QueryParams(), &params.Step)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter step: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetXmrigHashrateHistory(ctx, params)
+	return err
+}
+
 // GetXmrigLogs converts echo context to params.
 func (w *ServerInterfaceWrapper) GetXmrigLogs(ctx echo.Context) error {
 	var err error
@@ -814,11 +3257,145 @@ func (w *ServerInterfaceWrapper) GetXmrigLogs(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter n: %s", err))
 	}
 
+	// ------------- Optional query parameter "since_seq" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since_seq", ctx.QueryParams(), &params.SinceSeq)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter since_seq: %s", err))
+	}
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", ctx.QueryParams(), &params.Since)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter since: %s", err))
+	}
+
+	// ------------- Optional query parameter "until" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "until", ctx.QueryParams(), &params.Until)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter until: %s", err))
+	}
+
+	// ------------- Optional query parameter "history" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "history", ctx.QueryParams(), &params.History)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter history: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.GetXmrigLogs(ctx, params)
 	return err
 }
 
+// StreamXmrigLogs converts echo context to params.
+func (w *ServerInterfaceWrapper) StreamXmrigLogs(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamXmrigLogsParams
+	// ------------- Optional query parameter "backlog" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "backlog", ctx.QueryParams(), &params.Backlog)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter backlog: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.StreamXmrigLogs(ctx, params)
+	return err
+}
+
+// PauseXmrig converts echo context to params.
+func (w *ServerInterfaceWrapper) PauseXmrig(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PauseXmrig(ctx)
+	return err
+}
+
+// GetXmrigResourceHistory converts echo context to params.
+func (w *ServerInterfaceWrapper) GetXmrigResourceHistory(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetXmrigResourceHistoryParams
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", ctx.QueryParams(), &params.Since)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter since: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetXmrigResourceHistory(ctx, params)
+	return err
+}
+
+// RestartXmrig converts echo context to params.
+func (w *ServerInterfaceWrapper) RestartXmrig(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RestartXmrig(ctx)
+	return err
+}
+
+// GetXmrigSchedule converts echo context to params.
+func (w *ServerInterfaceWrapper) GetXmrigSchedule(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetXmrigSchedule(ctx)
+	return err
+}
+
+// StartXmrig converts echo context to params.
+func (w *ServerInterfaceWrapper) StartXmrig(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.StartXmrig(ctx)
+	return err
+}
+
+// StopXmrig converts echo context to params.
+func (w *ServerInterfaceWrapper) StopXmrig(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.StopXmrig(ctx)
+	return err
+}
+
+// GetXmrigTune converts echo context to params.
+func (w *ServerInterfaceWrapper) GetXmrigTune(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetXmrigTune(ctx)
+	return err
+}
+
+// TuneXmrig converts echo context to params.
+func (w *ServerInterfaceWrapper) TuneXmrig(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.TuneXmrig(ctx)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -851,6 +3428,18 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.GET(baseURL+"/metrics", wrapper.GetMetrics)
 	router.GET(baseURL+"/specs", wrapper.GetSpecs)
 	router.GET(baseURL+"/xmrig", wrapper.GetXmrigStatus)
+	router.POST(baseURL+"/xmrig/benchmark", wrapper.BenchmarkXmrig)
+	router.GET(baseURL+"/xmrig/benchmarks", wrapper.GetXmrigBenchmarks)
+	router.GET(baseURL+"/xmrig/hashrate/history", wrapper.GetXmrigHashrateHistory)
 	router.GET(baseURL+"/xmrig/logs", wrapper.GetXmrigLogs)
+	router.GET(baseURL+"/xmrig/logs/stream", wrapper.StreamXmrigLogs)
+	router.POST(baseURL+"/xmrig/pause", wrapper.PauseXmrig)
+	router.GET(baseURL+"/xmrig/resources/history", wrapper.GetXmrigResourceHistory)
+	router.POST(baseURL+"/xmrig/restart", wrapper.RestartXmrig)
+	router.GET(baseURL+"/xmrig/schedule", wrapper.GetXmrigSchedule)
+	router.POST(baseURL+"/xmrig/start", wrapper.StartXmrig)
+	router.POST(baseURL+"/xmrig/stop", wrapper.StopXmrig)
+	router.GET(baseURL+"/xmrig/tune", wrapper.GetXmrigTune)
+	router.POST(baseURL+"/xmrig/tune", wrapper.TuneXmrig)
 
 }